@@ -7,7 +7,8 @@
 // # Key Features
 //   - Supports the graceful shutdown of servers by waiting for ongoing requests to finish.
 //   - Manages multiple servers, allowing them to be started and stopped.
-//   - Interrupt signal handling, listens for system signals (SIGINT, SIGTERM) to initiate the shutdown process.
+//   - Resources such as asynchronous log handlers and rotating log files are closed in the shutdown process with [NewGracefulCloser].
+//   - Interrupt signal handling, listens for system signals (SIGINT, SIGTERM) to initiate the shutdown process, configurable with [WithSignals].
 //   - Configurable timeout support for shutdown operations, with forced stop functionality if timeout is exceeded.
 //   - Error management, automatically triggers shutdown procedures if any server encounters startup errors, maintaining application stability.
 //   - Once-executed guarantee, The [GracefulShutdown] interface is executed exactly once to avoid conflicting shutdown actions.
@@ -18,6 +19,7 @@
 //   - A [GracefulShutdown] handler is created using [NewGracefulShutdown] and requires registering one or more [GracefulServer] instances.
 //   - For each server, a [GracefulServer] is created using [NewGracefulServer].
 //   - A [GracefulServer] specifically for an [http.Server] is created using [NewGracefulServerHttp].
+//   - A [GracefulServer] that closes a resource, such as a log handler, is created using [NewGracefulCloser].
 //
 // 2. Startup Phase
 //   - When the Run method on [GracefulShutdown] is called, starts all registered servers by calling each server's Start method.
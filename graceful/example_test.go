@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/telmoandrade/go-library/graceful"
+	"github.com/telmoandrade/go-library/logger"
 )
 
 func ExampleNewGracefulShutdown() {
@@ -68,3 +71,20 @@ func ExampleNewGracefulServerHttp() {
 	gs.Run(ctx)
 	// Output:
 }
+
+func ExampleNewGracefulCloser() {
+	ctx, stop := context.WithCancel(context.Background())
+	stop()
+
+	ah := logger.NewAsyncHandler(slog.NewTextHandler(os.Stdout, nil))
+	slog.SetDefault(logger.NewLogger(logger.WithHandler(ah)))
+
+	gs := graceful.NewGracefulShutdown(
+		graceful.WithServers(
+			graceful.NewGracefulCloser(ah),
+		),
+	)
+
+	gs.Run(ctx)
+	// Output:
+}
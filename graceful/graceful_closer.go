@@ -0,0 +1,36 @@
+package graceful
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+type (
+	// Closer defines a resource that must be closed when the application shuts down,
+	// such as the asynchronous log handler of the logger package.
+	// It is used as a parameter for the [NewGracefulCloser].
+	Closer interface {
+		// Close releases the resource, it has a context parameter to manage timeout signals.
+		Close(ctx context.Context) error
+	}
+)
+
+// NewGracefulCloser returns a new [GracefulServer] that closes the [Closer] in the shutdown process.
+// The start does nothing, and the Stop method calls Close with the shutdown context.
+//
+// Important Note:
+//   - The servers are stopped concurrently, the logs written by other servers after the closer is closed must still be handled by the resource.
+func NewGracefulCloser(c Closer) GracefulServer {
+	if c == nil {
+		return nil
+	}
+
+	return NewGracefulServer(
+		WithStop(func(ctx context.Context) {
+			if err := c.Close(ctx); err != nil {
+				slog.Error(fmt.Sprintf("[CLOSER] Error closing: %s", err.Error()))
+			}
+		}),
+	)
+}
@@ -0,0 +1,53 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type closerFunc func(ctx context.Context) error
+
+func (fn closerFunc) Close(ctx context.Context) error { return fn(ctx) }
+
+func TestNewGracefulCloser(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    error
+		wantNil bool
+	}{
+		{name: "nil", wantNil: true},
+		{name: "close"},
+		{name: "close error", args: errors.New("error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			closed := false
+			var c Closer
+			if !tt.wantNil {
+				c = closerFunc(func(ctx context.Context) error {
+					closed = true
+					return tt.args
+				})
+			}
+
+			got := NewGracefulCloser(c)
+			if (got == nil) != tt.wantNil {
+				t.Fatalf("NewGracefulCloser() = %v, want nil %v", got, tt.wantNil)
+			}
+			if got == nil {
+				return
+			}
+
+			if err := got.Start(); err != nil {
+				t.Errorf("Start() error = %v", err)
+			}
+			got.Stop(context.Background())
+			got.ForceStop()
+
+			if !closed {
+				t.Errorf("Close() not called")
+			}
+		})
+	}
+}
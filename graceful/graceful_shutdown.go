@@ -7,6 +7,7 @@ import (
 	"os/signal"
 	"slices"
 	"sync"
+	"syscall"
 	"time"
 )
 
@@ -19,6 +20,7 @@ type (
 		gracefulServers []GracefulServer
 		once            sync.Once
 		notifyShutdown  func()
+		signals         []os.Signal
 	}
 
 	// GracefulShutdown is responsible for managing the lifecycle of the graceful shutdown handler, overseeing the startup, shutdown,
@@ -45,6 +47,7 @@ func NewGracefulShutdown(opts ...OptionGracefulShutdown) GracefulShutdown {
 		cancelCtx:       cancelCtx,
 		gracefulServers: []GracefulServer{},
 		notifyShutdown:  func() {},
+		signals:         []os.Signal{os.Interrupt, syscall.SIGTERM},
 	}
 
 	for _, opt := range opts {
@@ -85,6 +88,21 @@ func WithNotifyShutdown(fn func()) OptionGracefulShutdown {
 	}
 }
 
+// WithSignals is an [OptionGracefulShutdown] that defines the system signals that initiate the shutdown process.
+//
+// Default:
+//   - The default signals are SIGINT [os.Interrupt] and SIGTERM, sent by Kubernetes and systemd to stop the process.
+//
+// Important Note:
+//   - Without signals, the shutdown process is initiated only by the context of [GracefulShutdown.Run] or by a server failing to start.
+func WithSignals(signals ...os.Signal) OptionGracefulShutdown {
+	return func(gs *gracefulShutdown) {
+		gs.signals = slices.DeleteFunc(slices.Clone(signals), func(s os.Signal) bool {
+			return s == nil
+		})
+	}
+}
+
 func (gs *gracefulShutdown) runServer(s GracefulServer) {
 	gs.wg.Add(1)
 
@@ -129,7 +147,10 @@ func (gs *gracefulShutdown) Run(ctx context.Context) {
 			gs.runServer(s)
 		}
 
-		signalCtx, cancelCtx := signal.NotifyContext(ctx, os.Interrupt)
+		signalCtx, cancelCtx := context.WithCancel(ctx)
+		if len(gs.signals) > 0 {
+			signalCtx, cancelCtx = signal.NotifyContext(ctx, gs.signals...)
+		}
 
 		go func() {
 			<-gs.ctx.Done()
@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"os/signal"
 	"reflect"
 	"sync"
+	"syscall"
 	"testing"
 	"time"
 
@@ -168,6 +171,43 @@ func TestWithNotifyShutdown(t *testing.T) {
 	}
 }
 
+func TestWithSignals(t *testing.T) {
+	tests := []struct {
+		name string
+		args []os.Signal
+		want []os.Signal
+	}{
+		{
+			name: "default",
+			want: []os.Signal{os.Interrupt, syscall.SIGTERM},
+		},
+		{
+			name: "custom",
+			args: []os.Signal{syscall.SIGHUP, nil},
+			want: []os.Signal{syscall.SIGHUP},
+		},
+		{
+			name: "empty",
+			args: []os.Signal{},
+			want: []os.Signal{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []OptionGracefulShutdown{}
+			if tt.args != nil {
+				opts = append(opts, WithSignals(tt.args...))
+			}
+			n := NewGracefulShutdown(opts...)
+			gs, _ := n.(*gracefulShutdown)
+
+			if got := gs.signals; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WithSignals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_gracefulShutdown_runServer(t *testing.T) {
 	t.Run("cancel control context", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -278,4 +318,42 @@ func Test_gracefulShutdown_Run(t *testing.T) {
 			t.Errorf("NotifyShutdown call %v want 1", callNotifyShutdown)
 		}
 	})
+
+	t.Run("SIGTERM", func(t *testing.T) {
+		// The signal is also delivered to the test, so the default behavior does not terminate the process.
+		sigterm := make(chan os.Signal, 1)
+		signal.Notify(sigterm, syscall.SIGTERM)
+		defer signal.Stop(sigterm)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mock := NewMockGracefulServer(ctrl)
+		mock.EXPECT().Start().Return(nil)
+		mock.EXPECT().Stop(gomock.Any())
+
+		gs := NewGracefulShutdown(WithServers(mock))
+
+		done := make(chan struct{})
+		go func() {
+			gs.Run(context.Background())
+			close(done)
+		}()
+
+		p, err := os.FindProcess(os.Getpid())
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The signal is sent until Run handles it, it may be sent before Run listens for it.
+		for {
+			if err := p.Signal(syscall.SIGTERM); err != nil {
+				t.Skipf("sending SIGTERM is not supported: %v", err)
+			}
+			select {
+			case <-done:
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	})
 }
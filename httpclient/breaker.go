@@ -0,0 +1,213 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type (
+	breakerState int
+
+	circuitBreaker struct {
+		threshold   int
+		openTimeout time.Duration
+		now         func() time.Time
+		mu          sync.Mutex
+		hosts       map[string]*hostBreaker
+		sweepAt     time.Time
+	}
+
+	hostBreaker struct {
+		state    breakerState
+		failures int
+		failedAt time.Time
+		openedAt time.Time
+		probing  bool
+	}
+
+	// OptionBreaker is used to apply configurations to the circuit breaker of the transport enabled with [WithCircuitBreaker].
+	OptionBreaker func(*circuitBreaker)
+)
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// ErrCircuitOpen is returned by the transport when the circuit breaker of the host is open, the request is not sent.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// WithCircuitBreaker is an [OptionClient] that stops sending requests to a host after consecutive failures.
+// A variadic set of [OptionBreaker] used to configure the circuit breaker.
+//
+// Behavior:
+//   - Each host has its own circuit breaker, a failure is a call without a response or with a response status >= 500.
+//   - After the threshold of consecutive failures the circuit opens, the requests fail with [ErrCircuitOpen] without being sent.
+//   - After the open timeout the circuit is half-open, a single request probes the host while the others still fail.
+//   - The circuit closes if the probe succeeds, otherwise it opens again for another timeout.
+//   - While the circuit is open or half-open, the results of the requests sent before it opened are ignored,
+//     only the probe closes the circuit.
+//   - The changes of the circuit state are logged.
+//   - Only the hosts with recent failures are kept, a host is removed after a success or after an open timeout without failures.
+//
+// Default:
+//   - The threshold is 5 consecutive failures.
+//   - The open timeout is 30s.
+func WithCircuitBreaker(opts ...OptionBreaker) OptionClient {
+	return func(t *transport) {
+		cb := &circuitBreaker{
+			threshold:   5,
+			openTimeout: 30 * time.Second,
+			now:         time.Now,
+			hosts:       map[string]*hostBreaker{},
+		}
+
+		for _, opt := range opts {
+			opt(cb)
+		}
+
+		t.breaker = cb
+	}
+}
+
+// WithBreakerThreshold is an [OptionBreaker] that defines the number of consecutive failures opening the circuit.
+// A value less than 1 is ignored.
+func WithBreakerThreshold(failures int) OptionBreaker {
+	return func(cb *circuitBreaker) {
+		if failures > 0 {
+			cb.threshold = failures
+		}
+	}
+}
+
+// WithBreakerOpenTimeout is an [OptionBreaker] that defines how long the circuit stays open before probing the host.
+// A value less than or equal to zero is ignored.
+func WithBreakerOpenTimeout(timeout time.Duration) OptionBreaker {
+	return func(cb *circuitBreaker) {
+		if timeout > 0 {
+			cb.openTimeout = timeout
+		}
+	}
+}
+
+func (cb *circuitBreaker) host(host string) *hostBreaker {
+	hb, ok := cb.hosts[host]
+	if !ok {
+		hb = &hostBreaker{}
+		cb.hosts[host] = hb
+	}
+	return hb
+}
+
+// allow reports whether the request can be sent to the host, moving an expired open circuit to half-open.
+// It returns true when the request is the probe of the half-open circuit.
+func (cb *circuitBreaker) allow(ctx context.Context, host string) (bool, error) {
+	cb.mu.Lock()
+	hb, ok := cb.hosts[host]
+	if !ok {
+		cb.mu.Unlock()
+		return false, nil
+	}
+
+	halfOpen := false
+	switch hb.state {
+	case breakerOpen:
+		if cb.now().Sub(hb.openedAt) < cb.openTimeout {
+			cb.mu.Unlock()
+			return false, fmt.Errorf("%w: %s", ErrCircuitOpen, host)
+		}
+		hb.state = breakerHalfOpen
+		halfOpen = true
+	case breakerHalfOpen:
+		if hb.probing {
+			cb.mu.Unlock()
+			return false, fmt.Errorf("%w: %s", ErrCircuitOpen, host)
+		}
+	}
+
+	probe := hb.state == breakerHalfOpen
+	hb.probing = probe
+	cb.mu.Unlock()
+
+	if halfOpen {
+		slog.InfoContext(ctx, "[HTTP CLIENT] Circuit breaker half-open", slog.String("host", host))
+	}
+	return probe, nil
+}
+
+// done records the result of the request sent to the host, probe reports whether it is the probe of the half-open circuit.
+// The host is removed after a success, so only the hosts with failures are kept.
+func (cb *circuitBreaker) done(ctx context.Context, host string, probe, ok bool) {
+	cb.mu.Lock()
+	now := cb.now()
+	cb.sweep(now)
+
+	// While the circuit is not closed, only the result of the probe changes it,
+	// the requests sent before the circuit opened may still finish.
+	if hb, found := cb.hosts[host]; found && hb.state != breakerClosed && !probe {
+		cb.mu.Unlock()
+		return
+	}
+
+	if ok {
+		_, found := cb.hosts[host]
+		delete(cb.hosts, host)
+		cb.mu.Unlock()
+
+		if found && probe {
+			slog.InfoContext(ctx, "[HTTP CLIENT] Circuit breaker closed", slog.String("host", host))
+		}
+		return
+	}
+
+	hb := cb.host(host)
+	hb.probing = false
+	hb.failures++
+	hb.failedAt = now
+
+	opened := hb.state == breakerHalfOpen || hb.failures >= cb.threshold
+	if opened {
+		hb.state = breakerOpen
+		hb.openedAt = now
+	}
+	failures := hb.failures
+	cb.mu.Unlock()
+
+	if opened {
+		slog.WarnContext(ctx, "[HTTP CLIENT] Circuit breaker open",
+			slog.String("host", host),
+			slog.Int("failures", failures),
+			slog.Duration("timeout", cb.openTimeout),
+		)
+	}
+}
+
+// release discards the probe of the half-open circuit without a result, such as when the probe is canceled.
+func (cb *circuitBreaker) release(host string) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if hb, ok := cb.hosts[host]; ok {
+		hb.probing = false
+	}
+}
+
+// sweep removes the closed hosts without failures in the last open timeout, at most once in each open timeout.
+// It must be called with the lock held.
+func (cb *circuitBreaker) sweep(now time.Time) {
+	if now.Before(cb.sweepAt) {
+		return
+	}
+	cb.sweepAt = now.Add(cb.openTimeout)
+
+	for host, hb := range cb.hosts {
+		if hb.state == breakerClosed && now.Sub(hb.failedAt) >= cb.openTimeout {
+			delete(cb.hosts, host)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/telmoandrade/go-library/logger/logtest"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []OptionBreaker
+		wantThreshold   int
+		wantOpenTimeout time.Duration
+	}{
+		{name: "default", wantThreshold: 5, wantOpenTimeout: 30 * time.Second},
+		{name: "invalid options", opts: []OptionBreaker{WithBreakerThreshold(0), WithBreakerOpenTimeout(0)}, wantThreshold: 5, wantOpenTimeout: 30 * time.Second},
+		{name: "options", opts: []OptionBreaker{WithBreakerThreshold(2), WithBreakerOpenTimeout(time.Minute)}, wantThreshold: 2, wantOpenTimeout: time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &transport{}
+			WithCircuitBreaker(tt.opts...)(tr)
+
+			if tr.breaker.threshold != tt.wantThreshold {
+				t.Errorf("threshold = %v, want %v", tr.breaker.threshold, tt.wantThreshold)
+			}
+			if tr.breaker.openTimeout != tt.wantOpenTimeout {
+				t.Errorf("openTimeout = %v, want %v", tr.breaker.openTimeout, tt.wantOpenTimeout)
+			}
+		})
+	}
+}
+
+func Test_circuitBreaker(t *testing.T) {
+	h := logtest.SetDefault(t)
+
+	now := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	tr := &transport{}
+	WithCircuitBreaker(WithBreakerThreshold(2), WithBreakerOpenTimeout(time.Minute))(tr)
+	cb := tr.breaker
+	cb.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	const host = "example.com"
+
+	steps := []struct {
+		name      string
+		advance   time.Duration
+		result    *bool
+		late      *bool
+		release   bool
+		wantAllow bool
+		wantProbe bool
+	}{
+		{name: "closed", wantAllow: true, result: ptr(false)},
+		{name: "closed after one failure", wantAllow: true, result: ptr(false)},
+		{name: "late success while open", late: ptr(true)},
+		{name: "open", wantAllow: false},
+		{name: "still open", advance: 59 * time.Second, wantAllow: false},
+		{name: "half-open probe", advance: time.Second, wantAllow: true, wantProbe: true},
+		{name: "late failure while half-open", late: ptr(false)},
+		{name: "late success while half-open", late: ptr(true)},
+		{name: "half-open while probing", wantAllow: false},
+		{name: "probe released", release: true},
+		{name: "half-open new probe", wantAllow: true, wantProbe: true, result: ptr(false)},
+		{name: "open again", wantAllow: false},
+		{name: "half-open after timeout", advance: time.Minute, wantAllow: true, wantProbe: true, result: ptr(true)},
+		{name: "closed after probe", wantAllow: true, result: ptr(true)},
+	}
+	for _, step := range steps {
+		now = now.Add(step.advance)
+
+		if step.release {
+			cb.release(host)
+			continue
+		}
+		if step.late != nil {
+			// The result of a request sent before the circuit opened.
+			cb.done(ctx, host, false, *step.late)
+			continue
+		}
+
+		probe, err := cb.allow(ctx, host)
+		if (err == nil) != step.wantAllow || probe != step.wantProbe {
+			t.Fatalf("%s: circuitBreaker.allow() = %v, %v, want probe %v, allow %v", step.name, probe, err, step.wantProbe, step.wantAllow)
+		}
+		if err != nil && !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("%s: circuitBreaker.allow() error = %v, want %v", step.name, err, ErrCircuitOpen)
+		}
+		if step.result != nil {
+			cb.done(ctx, host, probe, *step.result)
+		}
+	}
+
+	logtest.AssertCount(t, h, 2, logtest.Level(slog.LevelWarn), logtest.Message("[HTTP CLIENT] Circuit breaker open"), logtest.Attr("host", host))
+	logtest.AssertCount(t, h, 2, logtest.Message("[HTTP CLIENT] Circuit breaker half-open"))
+	logtest.AssertCount(t, h, 1, logtest.Message("[HTTP CLIENT] Circuit breaker closed"))
+}
+
+func Test_circuitBreaker_evict(t *testing.T) {
+	logtest.SetDefault(t)
+
+	now := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	tr := &transport{}
+	WithCircuitBreaker(WithBreakerThreshold(2), WithBreakerOpenTimeout(time.Minute))(tr)
+	cb := tr.breaker
+	cb.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	cb.done(ctx, "ok.example.com", false, true)
+	cb.done(ctx, "failed.example.com", false, false)
+	cb.done(ctx, "open.example.com", false, false)
+	cb.done(ctx, "open.example.com", false, false)
+	cb.done(ctx, "recovered.example.com", false, false)
+	cb.done(ctx, "recovered.example.com", false, true)
+
+	hosts := func() []string {
+		cb.mu.Lock()
+		defer cb.mu.Unlock()
+		return slices.Sorted(maps.Keys(cb.hosts))
+	}
+	if got, want := hosts(), []string{"failed.example.com", "open.example.com"}; !slices.Equal(got, want) {
+		t.Errorf("hosts = %v, want %v", got, want)
+	}
+
+	now = now.Add(time.Minute)
+	cb.done(ctx, "ok.example.com", false, true)
+	if got, want := hosts(), []string{"open.example.com"}; !slices.Equal(got, want) {
+		t.Errorf("hosts after the open timeout = %v, want %v", got, want)
+	}
+}
+
+// lockCheckHandler fails the test when a record is logged while the circuit breaker lock is held.
+type lockCheckHandler struct {
+	slog.Handler
+	t  *testing.T
+	cb *circuitBreaker
+}
+
+func (h *lockCheckHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.cb.mu.TryLock() {
+		h.t.Errorf("%s: logged with the lock held", r.Message)
+		return nil
+	}
+	h.cb.mu.Unlock()
+	return nil
+}
+
+func Test_circuitBreaker_logUnlocked(t *testing.T) {
+	tr := &transport{}
+	WithCircuitBreaker(WithBreakerThreshold(1), WithBreakerOpenTimeout(time.Nanosecond))(tr)
+	cb := tr.breaker
+
+	defaultLogger := slog.Default()
+	defer slog.SetDefault(defaultLogger)
+	slog.SetDefault(slog.New(&lockCheckHandler{Handler: slog.Default().Handler(), t: t, cb: cb}))
+
+	ctx := context.Background()
+	cb.done(ctx, "example.com", false, false)
+	time.Sleep(time.Millisecond)
+	probe, err := cb.allow(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("circuitBreaker.allow() error = %v", err)
+	}
+	cb.done(ctx, "example.com", probe, true)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,23 @@
+// Package httpclient provides an outbound [http.RoundTripper] that keeps the log correlation across services.
+//
+// # Key Features:
+//   - Propagates the log identifier [logger.ContextLogID] in the X-Logger-ID header, read by the httpserver MiddlewareLogging.
+//   - Propagates the minimum log level of the context [logger.ContextMinLevel] in the X-Logger-Level header [WithLevelPropagation].
+//   - Propagates the trace context with the W3C traceparent and tracestate headers.
+//   - Logs each outbound call in the same structured format as the httpserver MiddlewareLogging, with status-based log levels.
+//   - Retries the idempotent calls with exponential backoff and jitter, honoring the Retry-After header [WithRetry].
+//   - Overall and per-attempt timeouts [WithTimeout] [WithAttemptTimeout].
+//   - Circuit breaker for each host with half-open probing [WithCircuitBreaker].
+//   - OpenTelemetry client span for each attempt [WithTracerProvider].
+//
+// Example:
+//
+//	client := httpclient.NewClient(
+//		httpclient.WithRetry(),
+//		httpclient.WithTimeout(10*time.Second),
+//		httpclient.WithAttemptTimeout(3*time.Second),
+//		httpclient.WithCircuitBreaker(),
+//	)
+//	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com/orders", nil)
+//	resp, err := client.Do(req)
+package httpclient
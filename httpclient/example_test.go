@@ -0,0 +1,66 @@
+package httpclient_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/telmoandrade/go-library/httpclient"
+	"github.com/telmoandrade/go-library/logger"
+)
+
+func ExampleNewClient() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "X-Logger-ID: %s, X-Logger-Level: %s", r.Header.Get("X-Logger-ID"), r.Header.Get("X-Logger-Level"))
+	}))
+	defer srv.Close()
+
+	ctx, _ := logger.LogIdWith(context.Background(), "", func() string { return "1" })
+	ctx, _ = logger.MinLevel(ctx, "debug")
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	resp, err := httpclient.NewClient(httpclient.WithLevelPropagation(true)).Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	fmt.Println(string(body))
+	// Output:
+	// X-Logger-ID: 1, X-Logger-Level: debug
+}
+
+func ExampleWithRetry() {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	defer srv.Close()
+
+	client := httpclient.NewClient(
+		httpclient.WithRetry(
+			httpclient.WithRetryAttempts(3),
+			httpclient.WithRetryBackoff(10*time.Millisecond, time.Second),
+		),
+	)
+
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	fmt.Println(resp.StatusCode, string(body), attempts.Load())
+	// Output:
+	// 200 ok 3
+}
@@ -0,0 +1,148 @@
+package httpclient
+
+import (
+	"math/bits"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+type (
+	retryPolicy struct {
+		attempts  int
+		baseDelay time.Duration
+		maxDelay  time.Duration
+		methods   []string
+		statuses  []int
+	}
+
+	// OptionRetry is used to apply configurations to the retries of the transport enabled with [WithRetry].
+	OptionRetry func(*retryPolicy)
+)
+
+// jitter returns a random duration in [0, d), it is replaced in tests.
+var jitter = func(d time.Duration) time.Duration {
+	return rand.N(d)
+}
+
+// WithRetry is an [OptionClient] that retries the failed calls with exponential backoff and jitter.
+// A variadic set of [OptionRetry] used to configure the retries.
+//
+// Behavior:
+//   - A call is retried when it fails without a response, or when the response status is retryable.
+//   - Only the idempotent methods are retried, and any request with the Idempotency-Key header.
+//   - The delay doubles at each attempt, a random jitter of up to half of the delay spreads the retries of several clients.
+//   - The Retry-After header of the response is honored instead of the backoff, as seconds or as an HTTP date.
+//     If it is longer than the maximum delay, the response is returned without retrying.
+//   - A request with a body is only retried if the body can be read again by [http.Request.GetBody],
+//     like the requests created by [http.NewRequest] with a [bytes.Reader] or a [strings.Reader].
+//   - The calls are not retried after the context of the request is done, or when the circuit breaker is open.
+//
+// Default:
+//   - The maximum number of attempts is 3, including the first call.
+//   - The delay starts at 100ms and is limited to 5s.
+//   - The idempotent methods are GET, HEAD, OPTIONS, TRACE, PUT and DELETE.
+//   - The retryable statuses are 429, 502, 503 and 504.
+func WithRetry(opts ...OptionRetry) OptionClient {
+	return func(t *transport) {
+		rp := &retryPolicy{
+			attempts:  3,
+			baseDelay: 100 * time.Millisecond,
+			maxDelay:  5 * time.Second,
+			methods:   []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete},
+			statuses:  []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		}
+
+		for _, opt := range opts {
+			opt(rp)
+		}
+
+		t.retry = rp
+	}
+}
+
+// WithRetryAttempts is an [OptionRetry] that defines the maximum number of attempts, including the first call.
+// A value less than 1 is ignored.
+func WithRetryAttempts(attempts int) OptionRetry {
+	return func(rp *retryPolicy) {
+		if attempts > 0 {
+			rp.attempts = attempts
+		}
+	}
+}
+
+// WithRetryBackoff is an [OptionRetry] that defines the delay before the first retry and the maximum delay.
+// Values less than or equal to zero are ignored.
+func WithRetryBackoff(base, maxDelay time.Duration) OptionRetry {
+	return func(rp *retryPolicy) {
+		if base > 0 {
+			rp.baseDelay = base
+		}
+		if maxDelay > 0 {
+			rp.maxDelay = maxDelay
+		}
+	}
+}
+
+// WithRetryMethods is an [OptionRetry] that replaces the methods retried, like POST for an API known to be idempotent.
+func WithRetryMethods(methods ...string) OptionRetry {
+	return func(rp *retryPolicy) {
+		rp.methods = methods
+	}
+}
+
+// WithRetryStatuses is an [OptionRetry] that replaces the response statuses retried.
+func WithRetryStatuses(statuses ...int) OptionRetry {
+	return func(rp *retryPolicy) {
+		rp.statuses = statuses
+	}
+}
+
+// allowed reports whether the request can be retried, by its method and its body.
+func (rp *retryPolicy) allowed(r *http.Request) bool {
+	if !slices.Contains(rp.methods, r.Method) && r.Header.Get("Idempotency-Key") == "" {
+		return false
+	}
+	return r.Body == nil || r.Body == http.NoBody || r.GetBody != nil
+}
+
+// retryable reports whether the attempt failed and can be retried, by its response or its error.
+func (rp *retryPolicy) retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return slices.Contains(rp.statuses, resp.StatusCode)
+}
+
+// delay returns the delay before the next attempt, false if the Retry-After header is longer than the maximum delay.
+func (rp *retryPolicy) delay(attempt int, resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After"), now); ok {
+			return d, d <= rp.maxDelay
+		}
+	}
+
+	// The delay is the maximum when doubling the base exceeds it, checked before shifting to avoid an overflow.
+	d := rp.maxDelay
+	if shift := attempt - 1; shift >= 0 && shift < bits.Len64(uint64(rp.maxDelay/rp.baseDelay)) {
+		d = rp.baseDelay << shift
+	}
+	half := d / 2
+	return half + jitter(d-half+1), true
+}
+
+// retryAfter parses the Retry-After header, as seconds or as an HTTP date.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,207 @@
+package httpclient
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithRetry(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []OptionRetry
+		want retryPolicy
+	}{
+		{
+			name: "default",
+			want: retryPolicy{
+				attempts:  3,
+				baseDelay: 100 * time.Millisecond,
+				maxDelay:  5 * time.Second,
+				methods:   []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete},
+				statuses:  []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+			},
+		},
+		{
+			name: "invalid options",
+			opts: []OptionRetry{WithRetryAttempts(0), WithRetryBackoff(0, -1)},
+			want: retryPolicy{
+				attempts:  3,
+				baseDelay: 100 * time.Millisecond,
+				maxDelay:  5 * time.Second,
+				methods:   []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete},
+				statuses:  []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+			},
+		},
+		{
+			name: "options",
+			opts: []OptionRetry{
+				WithRetryAttempts(5),
+				WithRetryBackoff(time.Second, time.Minute),
+				WithRetryMethods(http.MethodPost),
+				WithRetryStatuses(http.StatusInternalServerError),
+			},
+			want: retryPolicy{
+				attempts:  5,
+				baseDelay: time.Second,
+				maxDelay:  time.Minute,
+				methods:   []string{http.MethodPost},
+				statuses:  []int{http.StatusInternalServerError},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &transport{}
+			WithRetry(tt.opts...)(tr)
+
+			got := tr.retry
+			if got.attempts != tt.want.attempts || got.baseDelay != tt.want.baseDelay || got.maxDelay != tt.want.maxDelay ||
+				!slices.Equal(got.methods, tt.want.methods) || !slices.Equal(got.statuses, tt.want.statuses) {
+				t.Errorf("WithRetry() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_retryPolicy_allowed(t *testing.T) {
+	rp := &retryPolicy{methods: []string{http.MethodGet, http.MethodPut}}
+
+	withoutGetBody := httptest.NewRequest(http.MethodPut, "/", strings.NewReader("{}"))
+	withoutGetBody.GetBody = nil
+
+	idempotencyKey := httptest.NewRequest(http.MethodPost, "/", nil)
+	idempotencyKey.Header.Set("Idempotency-Key", "1")
+
+	withGetBody, _ := http.NewRequest(http.MethodPut, "/", strings.NewReader("{}"))
+
+	tests := []struct {
+		name string
+		r    *http.Request
+		want bool
+	}{
+		{name: "idempotent", r: httptest.NewRequest(http.MethodGet, "/", nil), want: true},
+		{name: "not idempotent", r: httptest.NewRequest(http.MethodPost, "/", nil), want: false},
+		{name: "idempotency key", r: idempotencyKey, want: true},
+		{name: "body without get body", r: withoutGetBody, want: false},
+		{name: "body with get body", r: withGetBody, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rp.allowed(tt.r); got != tt.want {
+				t.Errorf("retryPolicy.allowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_retryPolicy_retryable(t *testing.T) {
+	rp := &retryPolicy{statuses: []int{http.StatusServiceUnavailable}}
+
+	tests := []struct {
+		name string
+		resp *http.Response
+		err  error
+		want bool
+	}{
+		{name: "error", err: errors.New("dial failed"), want: true},
+		{name: "retryable status", resp: &http.Response{StatusCode: http.StatusServiceUnavailable}, want: true},
+		{name: "other status", resp: &http.Response{StatusCode: http.StatusInternalServerError}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rp.retryable(tt.resp, tt.err); got != tt.want {
+				t.Errorf("retryPolicy.retryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_retryPolicy_delay(t *testing.T) {
+	defer func(previous func(time.Duration) time.Duration) { jitter = previous }(jitter)
+	jitter = func(d time.Duration) time.Duration { return d - 1 }
+
+	now := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	rp := &retryPolicy{baseDelay: 100 * time.Millisecond, maxDelay: time.Second}
+
+	withHeader := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{value}}}
+	}
+
+	tests := []struct {
+		name    string
+		attempt int
+		resp    *http.Response
+		want    time.Duration
+		wantOk  bool
+	}{
+		{name: "first attempt", attempt: 1, want: 100 * time.Millisecond, wantOk: true},
+		{name: "second attempt", attempt: 2, resp: &http.Response{}, want: 200 * time.Millisecond, wantOk: true},
+		{name: "maximum delay", attempt: 5, want: time.Second, wantOk: true},
+		{name: "large attempt", attempt: 100, want: time.Second, wantOk: true},
+		{name: "shift overflow", attempt: 40, want: time.Second, wantOk: true},
+		{name: "retry after seconds", attempt: 1, resp: withHeader("1"), want: time.Second, wantOk: true},
+		{name: "retry after date", attempt: 1, resp: withHeader(now.Add(500 * time.Millisecond).Format(http.TimeFormat)), want: 0, wantOk: true},
+		{name: "retry after too long", attempt: 1, resp: withHeader("2"), want: 2 * time.Second, wantOk: false},
+		{name: "retry after invalid", attempt: 1, resp: withHeader("soon"), want: 100 * time.Millisecond, wantOk: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOk := rp.delay(tt.attempt, tt.resp, now)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("retryPolicy.delay() = %v, %v, want %v, %v", got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_retryPolicy_delay_attempts(t *testing.T) {
+	tr := &transport{}
+	WithRetry(WithRetryAttempts(64), WithRetryBackoff(5*time.Second, time.Hour))(tr)
+
+	now := time.Now()
+	for attempt := 1; attempt <= tr.retry.attempts; attempt++ {
+		got, ok := tr.retry.delay(attempt, nil, now)
+		if !ok || got < tr.retry.baseDelay/2 || got > tr.retry.maxDelay {
+			t.Fatalf("retryPolicy.delay(%d) = %v, %v, want (%v, %v], true", attempt, got, ok, tr.retry.baseDelay/2, tr.retry.maxDelay)
+		}
+	}
+}
+
+func Test_retryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{name: "empty", value: "", wantOk: false},
+		{name: "seconds", value: "3", want: 3 * time.Second, wantOk: true},
+		{name: "negative seconds", value: "-3", wantOk: false},
+		{name: "date", value: now.Add(time.Minute).Format(http.TimeFormat), want: time.Minute, wantOk: true},
+		{name: "past date", value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOk: true},
+		{name: "invalid", value: "soon", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOk := retryAfter(tt.value, now)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("retryAfter() = %v, %v, want %v, %v", got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_jitter(t *testing.T) {
+	for range 100 {
+		if got := jitter(10); got < 0 || got >= 10 {
+			t.Fatalf("jitter() = %v, want [0, 10)", got)
+		}
+	}
+}
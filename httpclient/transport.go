@@ -0,0 +1,398 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/telmoandrade/go-library/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	transport struct {
+		base             http.RoundTripper
+		logIDHeader      string
+		levelPropagation bool
+		propagator       propagation.TextMapPropagator
+		tracer           trace.Tracer
+		timeout          time.Duration
+		attemptTimeout   time.Duration
+		retry            *retryPolicy
+		breaker          *circuitBreaker
+	}
+
+	attemptResult struct {
+		resp   *http.Response
+		err    error
+		since  time.Duration
+		cancel context.CancelFunc
+		// sent reports whether the request reached the base transport, otherwise the error is not retried.
+		sent bool
+	}
+
+	// cancelBody releases the timeouts of the call when the response body is closed.
+	cancelBody struct {
+		io.ReadCloser
+		cancel context.CancelFunc
+	}
+
+	// OptionClient is used to apply configurations to the transport when creating it with [NewTransport] or [NewClient].
+	OptionClient func(*transport)
+)
+
+const tracerName = "github.com/telmoandrade/go-library/httpclient"
+
+var _ http.RoundTripper = &transport{}
+
+// NewClient returns a new [http.Client] using the transport created with [NewTransport].
+// A variadic set of [OptionClient] used to configure the behavior of the transport.
+func NewClient(opts ...OptionClient) *http.Client {
+	return &http.Client{
+		Transport: NewTransport(opts...),
+	}
+}
+
+// NewTransport returns a new [http.RoundTripper] that propagates the log correlation and logs each outbound call.
+// A variadic set of [OptionClient] used to configure the behavior of the transport.
+//
+// Propagation Handling:
+//   - The log identifier of the context [logger.ContextLogID] is sent in the X-Logger-ID header.
+//   - The minimum log level of the context [logger.ContextMinLevel] is sent in the X-Logger-Level header, like debug,
+//     only with [WithLevelPropagation].
+//   - The trace context of the span in the context is sent in the traceparent and tracestate headers.
+//   - The headers already present in the request are replaced, the request itself is not modified.
+//
+// Response Status Handling:
+//   - Error: For response status < 100 and >= 500, and when the call fails without a response
+//   - Warn: For response status < 200 and >= 400, and for the failed attempts that are retried
+//   - Info: Other response status
+//
+// Resilience Handling:
+//   - Each attempt is logged with request.attempt, and with retry.delay when it is retried [WithRetry].
+//   - Each attempt has its own OpenTelemetry client span, annotated with log.id and http.request.resend_count [WithTracerProvider].
+//   - The overall and the per-attempt timeouts are defined by [WithTimeout] and [WithAttemptTimeout].
+//   - The hosts failing repeatedly are not called for a while [WithCircuitBreaker].
+//
+// Important Note:
+//   - The call is logged when the response headers are received, the response size is the Content-Length header.
+//   - The query string of the URL is not logged, it may contain sensitive data.
+//   - The X-Logger-Level header is sent without the X-Logger-Token header, the downstream service only accepts it
+//     when the request is authorized by verifiers not based on the token, like VerifyCIDR of the httpserver package.
+func NewTransport(opts ...OptionClient) http.RoundTripper {
+	t := &transport{
+		base:        http.DefaultTransport,
+		logIDHeader: "X-Logger-ID",
+		propagator:  propagation.TraceContext{},
+		tracer:      otel.GetTracerProvider().Tracer(tracerName),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// WithBaseTransport is an [OptionClient] that defines the [http.RoundTripper] used to send the requests.
+//
+// Default:
+//   - The default transport is [http.DefaultTransport].
+func WithBaseTransport(base http.RoundTripper) OptionClient {
+	return func(t *transport) {
+		if base != nil {
+			t.base = base
+		}
+	}
+}
+
+// WithLogIDHeader is an [OptionClient] that defines the header name used to propagate the log identifier.
+//
+// Default:
+//   - The default header name is X-Logger-ID.
+func WithLogIDHeader(name string) OptionClient {
+	return func(t *transport) {
+		if name != "" {
+			t.logIDHeader = name
+		}
+	}
+}
+
+// WithLevelPropagation is an [OptionClient] that enables sending the minimum log level of the context [logger.ContextMinLevel]
+// in the X-Logger-Level header.
+//
+// Default:
+//   - The minimum log level is not propagated.
+//
+// Important Note:
+//   - The context level is also set by the route level of the httpserver WithLogLevel, not only by an authorized X-Logger-Level header,
+//     so it should be enabled only for downstream services trusting the caller.
+func WithLevelPropagation(enabled bool) OptionClient {
+	return func(t *transport) {
+		t.levelPropagation = enabled
+	}
+}
+
+// WithPropagator is an [OptionClient] that defines the propagator of the trace context,
+// like the global propagator of [go.opentelemetry.io/otel.GetTextMapPropagator].
+//
+// Default:
+//   - The default propagator is the W3C [propagation.TraceContext].
+func WithPropagator(propagator propagation.TextMapPropagator) OptionClient {
+	return func(t *transport) {
+		if propagator != nil {
+			t.propagator = propagator
+		}
+	}
+}
+
+// WithTracerProvider is an [OptionClient] that defines the provider of the tracer creating a span for each attempt.
+//
+// Default:
+//   - The default provider is the global provider of [otel.GetTracerProvider].
+func WithTracerProvider(provider trace.TracerProvider) OptionClient {
+	return func(t *transport) {
+		if provider != nil {
+			t.tracer = provider.Tracer(tracerName)
+		}
+	}
+}
+
+// WithTimeout is an [OptionClient] that defines the overall timeout of the call, including the retries and the response body.
+// A value less than or equal to zero disables the timeout.
+func WithTimeout(timeout time.Duration) OptionClient {
+	return func(t *transport) {
+		t.timeout = timeout
+	}
+}
+
+// WithAttemptTimeout is an [OptionClient] that defines the timeout of each attempt, including its response body.
+// An attempt that times out is retried when the retries are enabled with [WithRetry].
+// A value less than or equal to zero disables the timeout.
+func WithAttemptTimeout(timeout time.Duration) OptionClient {
+	return func(t *transport) {
+		t.attemptTimeout = timeout
+	}
+}
+
+// RoundTrip sends the request with the propagation headers, retrying it if enabled, and logs each attempt.
+func (t *transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx, cancel := r.Context(), context.CancelFunc(func() {})
+	if t.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+	}
+
+	retry := t.retry != nil && t.retry.allowed(r)
+
+	for attempt := 1; ; attempt++ {
+		res := t.attempt(ctx, r, attempt)
+
+		var delay time.Duration
+		again := retry && attempt < t.retry.attempts && ctx.Err() == nil &&
+			res.sent && t.retry.retryable(res.resp, res.err)
+		if again {
+			delay, again = t.retry.delay(attempt, res.resp, time.Now())
+		}
+		if deadline, ok := ctx.Deadline(); again && ok && time.Until(deadline) < delay {
+			again = false
+		}
+
+		t.log(ctx, r, attempt, res, delay, again)
+
+		if !again {
+			if res.err != nil {
+				res.cancel()
+				cancel()
+				return nil, res.err
+			}
+			res.resp.Body = &cancelBody{ReadCloser: res.resp.Body, cancel: func() {
+				res.cancel()
+				cancel()
+			}}
+			return res.resp, nil
+		}
+
+		if res.resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(res.resp.Body, 4<<10))
+			res.resp.Body.Close()
+		}
+		res.cancel()
+
+		if err := sleep(ctx, delay); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+}
+
+// attempt sends the request once, within its own span and the attempt timeout.
+func (t *transport) attempt(ctx context.Context, r *http.Request, attempt int) attemptResult {
+	start := time.Now()
+
+	res := attemptResult{cancel: func() {}}
+	attemptCtx := ctx
+	if t.attemptTimeout > 0 {
+		attemptCtx, res.cancel = context.WithTimeout(ctx, t.attemptTimeout)
+	}
+
+	r = r.Clone(attemptCtx)
+	if attempt > 1 && r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			closeBody(r)
+			res.err = err
+			return res
+		}
+		r.Body = body
+	}
+
+	host := r.URL.Host
+	probe := false
+	if t.breaker != nil {
+		if probe, res.err = t.breaker.allow(ctx, host); res.err != nil {
+			closeBody(r)
+			return res
+		}
+	}
+
+	attemptCtx, span := t.tracer.Start(attemptCtx, "HTTP "+r.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(r.Method),
+			semconv.ServerAddress(r.URL.Hostname()),
+			semconv.URLFull(r.URL.Scheme+"://"+host+r.URL.Path),
+		),
+	)
+	defer span.End()
+
+	if attempt > 1 {
+		span.SetAttributes(semconv.HTTPRequestResendCount(attempt - 1))
+	}
+	if logID, _ := ctx.Value(logger.ContextLogID).(string); logID != "" {
+		span.SetAttributes(attribute.Key("log.id").String(logID))
+		r.Header.Set(t.logIDHeader, logID)
+	}
+	if level, ok := ctx.Value(logger.ContextMinLevel).(slog.Level); ok && t.levelPropagation {
+		r.Header.Set("X-Logger-Level", strings.ToLower(logger.LevelName(level)))
+	}
+	t.propagator.Inject(attemptCtx, propagation.HeaderCarrier(r.Header))
+
+	res.resp, res.err = t.base.RoundTrip(r.WithContext(attemptCtx))
+	res.sent = true
+	res.since = time.Since(start)
+
+	if res.err != nil {
+		span.RecordError(res.err)
+		span.SetStatus(codes.Error, res.err.Error())
+	} else {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(res.resp.StatusCode))
+		if res.resp.StatusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(res.resp.StatusCode))
+		}
+	}
+
+	if t.breaker != nil {
+		if res.err != nil && ctx.Err() != nil {
+			if probe {
+				t.breaker.release(host)
+			}
+		} else {
+			t.breaker.done(ctx, host, probe, res.err == nil && res.resp.StatusCode < http.StatusInternalServerError)
+		}
+	}
+
+	return res
+}
+
+// closeBody closes the body of the request not sent to the base transport, as required by [http.RoundTripper].
+func closeBody(r *http.Request) {
+	if r.Body != nil {
+		_ = r.Body.Close()
+	}
+}
+
+// log logs the attempt, with the delay before the next attempt when it is retried.
+func (t *transport) log(ctx context.Context, r *http.Request, attempt int, res attemptResult, delay time.Duration, again bool) {
+	slogAny := []any{
+		slog.Group("request",
+			slog.String("method", r.Method),
+			slog.String("host", r.URL.Host),
+			slog.String("path", r.URL.Path),
+			slog.Int64("size", r.ContentLength),
+			slog.Int("attempt", attempt),
+		),
+	}
+	if again {
+		slogAny = append(slogAny, slog.Group("retry",
+			slog.Float64("delay", delay.Seconds()),
+		))
+	}
+
+	level := slog.LevelInfo
+	var msg string
+	if res.err != nil {
+		slogAny = append(slogAny, logger.Err(res.err))
+		msg = fmt.Sprintf("HTTP Client error %v %s %s%s", sinceRound(res.since), r.Method, r.URL.Host, r.URL.Path)
+		level = slog.LevelError
+	} else {
+		slogAny = append(slogAny, slog.Group("response",
+			slog.Int("status", res.resp.StatusCode),
+			slog.Int64("size", res.resp.ContentLength),
+			slog.Float64("time", res.since.Seconds()),
+		))
+		msg = fmt.Sprintf("HTTP Client %03d %dB %v %s %s%s", res.resp.StatusCode, max(res.resp.ContentLength, 0), sinceRound(res.since), r.Method, r.URL.Host, r.URL.Path)
+
+		if res.resp.StatusCode < http.StatusContinue || res.resp.StatusCode >= http.StatusInternalServerError {
+			level = slog.LevelError
+		} else if res.resp.StatusCode < http.StatusOK || res.resp.StatusCode >= http.StatusBadRequest {
+			level = slog.LevelWarn
+		}
+	}
+
+	if again {
+		level = min(level, slog.LevelWarn)
+	}
+
+	slog.Log(ctx, level, msg, slogAny...)
+}
+
+// sleep waits for the delay, it returns the context error if the context is done first.
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close closes the response body and releases the timeouts of the call.
+func (cb *cancelBody) Close() error {
+	err := cb.ReadCloser.Close()
+	cb.cancel()
+	return err
+}
+
+func sinceRound(since time.Duration) time.Duration {
+	if since > time.Second {
+		since = since.Round(time.Second)
+	} else if since > time.Millisecond {
+		since = since.Round(time.Millisecond)
+	} else if since > time.Microsecond {
+		since = since.Round(time.Microsecond)
+	}
+
+	return since
+}
@@ -0,0 +1,668 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/telmoandrade/go-library/logger"
+	"github.com/telmoandrade/go-library/logger/logtest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// closeRecorder is a request body recording whether it was closed.
+type closeRecorder struct {
+	io.Reader
+	closed atomic.Bool
+}
+
+func (cr *closeRecorder) Close() error {
+	cr.closed.Store(true)
+	return nil
+}
+
+func Test_sinceRound(t *testing.T) {
+	tests := []struct {
+		name  string
+		since time.Duration
+		want  time.Duration
+	}{
+		{name: "nanosecond", since: 999, want: 999},
+		{name: "microsecond", since: 1500, want: 2 * time.Microsecond},
+		{name: "millisecond", since: 1500 * time.Microsecond, want: 2 * time.Millisecond},
+		{name: "second", since: 1500 * time.Millisecond, want: 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sinceRound(tt.since); got != tt.want {
+				t.Errorf("sinceRound() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTransport(t *testing.T) {
+	base := roundTripperFunc(func(r *http.Request) (*http.Response, error) { return nil, nil })
+
+	tests := []struct {
+		name            string
+		opts            []OptionClient
+		wantLogIDHeader string
+		wantPropagator  propagation.TextMapPropagator
+		wantBase        bool
+	}{
+		{
+			name:            "default",
+			wantLogIDHeader: "X-Logger-ID",
+			wantPropagator:  propagation.TraceContext{},
+		},
+		{
+			name:            "empty options",
+			opts:            []OptionClient{WithBaseTransport(nil), WithLogIDHeader(""), WithPropagator(nil)},
+			wantLogIDHeader: "X-Logger-ID",
+			wantPropagator:  propagation.TraceContext{},
+		},
+		{
+			name:            "options",
+			opts:            []OptionClient{WithBaseTransport(base), WithLogIDHeader("X-Request-ID"), WithPropagator(propagation.Baggage{})},
+			wantLogIDHeader: "X-Request-ID",
+			wantPropagator:  propagation.Baggage{},
+			wantBase:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := NewClient(tt.opts...).Transport.(*transport)
+			if tr.logIDHeader != tt.wantLogIDHeader {
+				t.Errorf("logIDHeader = %v, want %v", tr.logIDHeader, tt.wantLogIDHeader)
+			}
+			if tr.propagator != tt.wantPropagator {
+				t.Errorf("propagator = %T, want %T", tr.propagator, tt.wantPropagator)
+			}
+			if _, gotBase := tr.base.(roundTripperFunc); gotBase != tt.wantBase {
+				t.Errorf("base = %T", tr.base)
+			}
+		})
+	}
+}
+
+func Test_transport_RoundTrip_propagation(t *testing.T) {
+	logtest.SetDefault(t)
+
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer srv.Close()
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	withContext := func() context.Context {
+		ctx, _ := logger.LogIdWith(context.Background(), "", func() string { return "log-1" })
+		ctx, _ = logger.MinLevel(ctx, "trace")
+		return trace.ContextWithSpanContext(ctx, sc)
+	}
+
+	tests := []struct {
+		name string
+		opts []OptionClient
+		ctx  func() context.Context
+		want map[string]string
+	}{
+		{
+			name: "without context",
+			opts: []OptionClient{WithLevelPropagation(true)},
+			ctx:  context.Background,
+			want: map[string]string{"X-Logger-Id": "", "X-Logger-Level": "", "Traceparent": ""},
+		},
+		{
+			name: "without level propagation",
+			ctx:  withContext,
+			want: map[string]string{
+				"X-Logger-Id":    "log-1",
+				"X-Logger-Level": "",
+				"Traceparent":    "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			},
+		},
+		{
+			name: "with context",
+			opts: []OptionClient{WithLevelPropagation(true)},
+			ctx:  withContext,
+			want: map[string]string{
+				"X-Logger-Id":    "log-1",
+				"X-Logger-Level": "trace",
+				"Traceparent":    "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := http.NewRequestWithContext(tt.ctx(), http.MethodGet, srv.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := NewClient(tt.opts...).Do(r)
+			if err != nil {
+				t.Fatalf("Client.Do() error = %v", err)
+			}
+			resp.Body.Close()
+
+			for header, want := range tt.want {
+				if v := got.Get(header); v != want {
+					t.Errorf("header %s = %v, want %v", header, v, want)
+				}
+			}
+			if v := r.Header.Get("X-Logger-Id"); v != "" {
+				t.Errorf("request modified, header X-Logger-Id = %v", v)
+			}
+		})
+	}
+}
+
+func Test_transport_RoundTrip_logging(t *testing.T) {
+	errDial := errors.New("dial failed")
+
+	tests := []struct {
+		name      string
+		status    int
+		err       error
+		wantLevel slog.Level
+		wantMsg   string
+	}{
+		{name: "ok", status: http.StatusOK, wantLevel: slog.LevelInfo, wantMsg: "HTTP Client 200 2B"},
+		{name: "switching protocols", status: http.StatusSwitchingProtocols, wantLevel: slog.LevelWarn, wantMsg: "HTTP Client 101 2B"},
+		{name: "not found", status: http.StatusNotFound, wantLevel: slog.LevelWarn, wantMsg: "HTTP Client 404 2B"},
+		{name: "internal server error", status: http.StatusInternalServerError, wantLevel: slog.LevelError, wantMsg: "HTTP Client 500 2B"},
+		{name: "error", err: errDial, wantLevel: slog.LevelError, wantMsg: "HTTP Client error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := logtest.SetDefault(t)
+
+			tr := NewTransport(WithBaseTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return &http.Response{StatusCode: tt.status, ContentLength: 2, Body: http.NoBody}, nil
+			})))
+
+			r := httptest.NewRequest(http.MethodPost, "https://example.com/orders?token=secret", strings.NewReader("{}"))
+			_, err := tr.RoundTrip(r)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("transport.RoundTrip() error = %v, want %v", err, tt.err)
+			}
+
+			rec := logtest.AssertLogged(t, h,
+				logtest.Level(tt.wantLevel),
+				logtest.MessageContains(tt.wantMsg),
+				logtest.MessageContains("POST example.com/orders"),
+				logtest.Attr("request.method", http.MethodPost),
+				logtest.Attr("request.host", "example.com"),
+				logtest.Attr("request.path", "/orders"),
+				logtest.Attr("request.size", int64(2)),
+			)
+			if strings.Contains(rec.String(), "secret") {
+				t.Errorf("query string logged: %v", rec)
+			}
+
+			if tt.err != nil {
+				logtest.AssertLogged(t, h, logtest.Attr("error.message", tt.err.Error()))
+			} else {
+				logtest.AssertLogged(t, h,
+					logtest.Attr("response.status", int64(tt.status)),
+					logtest.Attr("response.size", int64(2)),
+					logtest.HasAttr("response.time"),
+				)
+			}
+		})
+	}
+}
+
+type testSpan struct {
+	noop.Span
+	name   string
+	attrs  []attribute.KeyValue
+	status codes.Code
+}
+
+func (s *testSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.attrs = append(s.attrs, kv...)
+}
+
+func (s *testSpan) SetStatus(code codes.Code, description string) {
+	s.status = code
+}
+
+func (s *testSpan) attr(key attribute.Key) attribute.Value {
+	for _, kv := range s.attrs {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+type testTracerProvider struct {
+	noop.TracerProvider
+	spans []*testSpan
+}
+
+func (tp *testTracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	return &testTracer{tp: tp}
+}
+
+type testTracer struct {
+	noop.Tracer
+	tp *testTracerProvider
+}
+
+func (tt *testTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	s := &testSpan{name: name}
+	cfg := trace.NewSpanStartConfig(opts...)
+	s.attrs = cfg.Attributes()
+	tt.tp.spans = append(tt.tp.spans, s)
+	return trace.ContextWithSpan(ctx, s), s
+}
+
+func Test_transport_RoundTrip_retry(t *testing.T) {
+	type want struct {
+		status   int
+		err      error
+		attempts int32
+		retries  int
+	}
+	tests := []struct {
+		name     string
+		opts     []OptionClient
+		method   string
+		body     func() (io.Reader, func() (io.ReadCloser, error))
+		statuses []int
+		header   http.Header
+		want     want
+	}{
+		{
+			name:     "success after retries",
+			opts:     []OptionClient{WithRetry(WithRetryBackoff(time.Millisecond, time.Millisecond))},
+			method:   http.MethodGet,
+			statuses: []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			want:     want{status: http.StatusOK, attempts: 3, retries: 2},
+		},
+		{
+			name:     "attempts exhausted",
+			opts:     []OptionClient{WithRetry(WithRetryAttempts(2), WithRetryBackoff(time.Millisecond, time.Millisecond))},
+			method:   http.MethodGet,
+			statuses: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			want:     want{status: http.StatusServiceUnavailable, attempts: 2, retries: 1},
+		},
+		{
+			name:     "not idempotent",
+			opts:     []OptionClient{WithRetry(WithRetryBackoff(time.Millisecond, time.Millisecond))},
+			method:   http.MethodPost,
+			statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			want:     want{status: http.StatusServiceUnavailable, attempts: 1},
+		},
+		{
+			name:     "without retry",
+			method:   http.MethodGet,
+			statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			want:     want{status: http.StatusServiceUnavailable, attempts: 1},
+		},
+		{
+			name:     "body replayed",
+			opts:     []OptionClient{WithRetry(WithRetryBackoff(time.Millisecond, time.Millisecond))},
+			method:   http.MethodPut,
+			body:     func() (io.Reader, func() (io.ReadCloser, error)) { return strings.NewReader(`{"id":1}`), nil },
+			statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			want:     want{status: http.StatusOK, attempts: 2, retries: 1},
+		},
+		{
+			name:   "body not replayed",
+			opts:   []OptionClient{WithRetry(WithRetryBackoff(time.Millisecond, time.Millisecond))},
+			method: http.MethodPut,
+			body: func() (io.Reader, func() (io.ReadCloser, error)) {
+				return strings.NewReader(`{"id":1}`), func() (io.ReadCloser, error) { return nil, errors.New("body consumed") }
+			},
+			statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			want:     want{err: errors.New("body consumed"), attempts: 1, retries: 1},
+		},
+		{
+			name:     "retry after too long",
+			opts:     []OptionClient{WithRetry(WithRetryBackoff(time.Millisecond, time.Second))},
+			method:   http.MethodGet,
+			statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			header:   http.Header{"Retry-After": []string{"2"}},
+			want:     want{status: http.StatusTooManyRequests, attempts: 1},
+		},
+		{
+			name:     "retry after beyond the timeout",
+			opts:     []OptionClient{WithRetry(WithRetryBackoff(time.Millisecond, 5*time.Second)), WithTimeout(time.Second)},
+			method:   http.MethodGet,
+			statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			header:   http.Header{"Retry-After": []string{"2"}},
+			want:     want{status: http.StatusTooManyRequests, attempts: 1},
+		},
+		{
+			name:     "circuit open",
+			opts:     []OptionClient{WithRetry(WithRetryBackoff(time.Millisecond, time.Millisecond)), WithCircuitBreaker(WithBreakerThreshold(1))},
+			method:   http.MethodGet,
+			statuses: []int{http.StatusBadGateway, http.StatusOK},
+			want:     want{err: ErrCircuitOpen, attempts: 1, retries: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := logtest.SetDefault(t)
+
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+				if body, _ := io.ReadAll(r.Body); tt.body != nil && string(body) != `{"id":1}` {
+					t.Errorf("attempt %d body = %s", n, body)
+				}
+				for k, v := range tt.header {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer srv.Close()
+
+			var body io.Reader
+			var getBody func() (io.ReadCloser, error)
+			if tt.body != nil {
+				body, getBody = tt.body()
+			}
+			r, _ := http.NewRequest(tt.method, srv.URL+"/orders", body)
+			if getBody != nil {
+				r.GetBody = getBody
+			}
+
+			resp, err := NewClient(tt.opts...).Do(r)
+			if tt.want.err != nil {
+				if err == nil || !strings.Contains(err.Error(), tt.want.err.Error()) {
+					t.Fatalf("Client.Do() error = %v, want %v", err, tt.want.err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Client.Do() error = %v", err)
+				}
+				resp.Body.Close()
+				if resp.StatusCode != tt.want.status {
+					t.Errorf("Client.Do() status = %v, want %v", resp.StatusCode, tt.want.status)
+				}
+			}
+
+			if got := attempts.Load(); got != tt.want.attempts {
+				t.Errorf("attempts = %v, want %v", got, tt.want.attempts)
+			}
+			logtest.AssertCount(t, h, tt.want.retries, logtest.Level(slog.LevelWarn), logtest.HasAttr("retry.delay"))
+			logtest.AssertLogged(t, h, logtest.Attr("request.attempt", tt.want.retries+1))
+		})
+	}
+}
+
+func Test_transport_RoundTrip_bodyClosed(t *testing.T) {
+	logtest.SetDefault(t)
+
+	errGetBody := errors.New("body consumed")
+	base := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Body != nil {
+			_ = r.Body.Close()
+		}
+		return &http.Response{StatusCode: http.StatusBadGateway, Body: http.NoBody}, nil
+	})
+
+	tests := []struct {
+		name    string
+		opts    []OptionClient
+		getBody func(body *closeRecorder) func() (io.ReadCloser, error)
+		want    error
+	}{
+		{
+			name: "circuit open",
+			opts: []OptionClient{WithCircuitBreaker(WithBreakerThreshold(1))},
+			want: ErrCircuitOpen,
+		},
+		{
+			name: "circuit open on retry",
+			opts: []OptionClient{WithCircuitBreaker(WithBreakerThreshold(1)), WithRetry(WithRetryBackoff(time.Millisecond, time.Millisecond))},
+			getBody: func(body *closeRecorder) func() (io.ReadCloser, error) {
+				return func() (io.ReadCloser, error) { return body, nil }
+			},
+			want: ErrCircuitOpen,
+		},
+		{
+			name: "body not replayed",
+			opts: []OptionClient{WithRetry(WithRetryBackoff(time.Millisecond, time.Millisecond))},
+			getBody: func(body *closeRecorder) func() (io.ReadCloser, error) {
+				return func() (io.ReadCloser, error) { return nil, errGetBody }
+			},
+			want: errGetBody,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := NewTransport(append(tt.opts, WithBaseTransport(base))...)
+
+			// The first call opens the circuit when the breaker is enabled.
+			if tt.getBody == nil {
+				r := httptest.NewRequest(http.MethodPut, "https://example.com/orders", nil)
+				if _, err := tr.RoundTrip(r); err != nil {
+					t.Fatalf("transport.RoundTrip() error = %v", err)
+				}
+			}
+
+			body := &closeRecorder{Reader: strings.NewReader("{}")}
+			retried := &closeRecorder{Reader: strings.NewReader("{}")}
+			r := httptest.NewRequest(http.MethodPut, "https://example.com/orders", body)
+			if tt.getBody != nil {
+				r.GetBody = tt.getBody(retried)
+			}
+
+			if _, err := tr.RoundTrip(r); !errors.Is(err, tt.want) {
+				t.Fatalf("transport.RoundTrip() error = %v, want %v", err, tt.want)
+			}
+			if !body.closed.Load() {
+				t.Errorf("request body closed = false, want true")
+			}
+			if tt.getBody != nil && tt.want == ErrCircuitOpen && !retried.closed.Load() {
+				t.Errorf("replayed body closed = false, want true")
+			}
+		})
+	}
+}
+
+func Test_transport_RoundTrip_timeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		opts    []OptionClient
+		wantErr error
+	}{
+		{
+			name:    "overall timeout",
+			opts:    []OptionClient{WithTimeout(20 * time.Millisecond)},
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name: "attempt timeout retried",
+			opts: []OptionClient{WithAttemptTimeout(20 * time.Millisecond), WithRetry(WithRetryBackoff(time.Millisecond, time.Millisecond))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logtest.SetDefault(t)
+			attempts.Store(0)
+
+			r, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+			resp, err := NewClient(tt.opts...).Do(r)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Client.Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer resp.Body.Close()
+
+			if body, _ := io.ReadAll(resp.Body); string(body) != "ok" {
+				t.Errorf("body = %s, want ok", body)
+			}
+		})
+	}
+}
+
+func Test_transport_RoundTrip_canceled(t *testing.T) {
+	logtest.SetDefault(t)
+
+	started := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	tr := NewTransport(WithCircuitBreaker(WithBreakerThreshold(1)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	r, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if _, err := tr.RoundTrip(r); !errors.Is(err, context.Canceled) {
+		t.Fatalf("transport.RoundTrip() error = %v, want %v", err, context.Canceled)
+	}
+
+	host := r.URL.Host
+	if _, err := tr.(*transport).breaker.allow(context.Background(), host); err != nil {
+		t.Errorf("circuitBreaker.allow() error = %v, want nil", err)
+	}
+}
+
+func Test_transport_RoundTrip_sleepCanceled(t *testing.T) {
+	logtest.SetDefault(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	r, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	_, err := NewTransport(WithRetry(WithRetryBackoff(time.Minute, time.Minute))).RoundTrip(r)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("transport.RoundTrip() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func Test_transport_RoundTrip_spans(t *testing.T) {
+	logtest.SetDefault(t)
+
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	tp := &testTracerProvider{}
+	tr := NewTransport(
+		WithTracerProvider(tp),
+		WithTracerProvider(nil),
+		WithRetry(WithRetryBackoff(time.Millisecond, time.Millisecond)),
+		WithBaseTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if r.Header.Get("X-Fail") != "" {
+				return nil, errors.New("dial failed")
+			}
+			return http.DefaultTransport.RoundTrip(r)
+		})),
+	)
+
+	ctx, _ := logger.LogIdWith(context.Background(), "", func() string { return "log-1" })
+	r, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/orders?token=secret", nil)
+	resp, err := tr.RoundTrip(r)
+	if err != nil {
+		t.Fatalf("transport.RoundTrip() error = %v", err)
+	}
+	resp.Body.Close()
+
+	r.Header.Set("X-Fail", "1")
+	if _, err := NewTransport(WithTracerProvider(tp), WithBaseTransport(tr.(*transport).base)).RoundTrip(r); err == nil {
+		t.Fatalf("transport.RoundTrip() error = nil")
+	}
+
+	if len(tp.spans) != 3 {
+		t.Fatalf("spans = %v, want 3", len(tp.spans))
+	}
+
+	tests := []struct {
+		span        *testSpan
+		wantStatus  int64
+		wantResend  int64
+		wantErrCode codes.Code
+	}{
+		{span: tp.spans[0], wantStatus: http.StatusServiceUnavailable, wantErrCode: codes.Error},
+		{span: tp.spans[1], wantStatus: http.StatusOK, wantResend: 1},
+		{span: tp.spans[2], wantErrCode: codes.Error},
+	}
+	for i, tt := range tests {
+		if tt.span.name != "HTTP GET" {
+			t.Errorf("span %d name = %v, want %v", i, tt.span.name, "HTTP GET")
+		}
+		if got := tt.span.attr("log.id").AsString(); got != "log-1" {
+			t.Errorf("span %d log.id = %v, want %v", i, got, "log-1")
+		}
+		if got := tt.span.attr("url.full").AsString(); got != srv.URL+"/orders" {
+			t.Errorf("span %d url.full = %v, want %v", i, got, srv.URL+"/orders")
+		}
+		if got := tt.span.attr("http.response.status_code").AsInt64(); got != tt.wantStatus {
+			t.Errorf("span %d status = %v, want %v", i, got, tt.wantStatus)
+		}
+		if got := tt.span.attr("http.request.resend_count").AsInt64(); got != tt.wantResend {
+			t.Errorf("span %d resend count = %v, want %v", i, got, tt.wantResend)
+		}
+		if tt.span.status != tt.wantErrCode {
+			t.Errorf("span %d status code = %v, want %v", i, tt.span.status, tt.wantErrCode)
+		}
+	}
+}
@@ -20,6 +20,13 @@
 //   - [WithCors]: Defines information that will be used in the handlers for CORS processing.
 //   - [WithHandlerNotFound]: Defines the handler for handling not found routes.
 //   - [WithHandlerMethodNotAllowed]: Defines the handler not allowed methods routes.
+//   - [WithOpenAPI]: Serves the OpenAPI 3.1 document of the registered handlers, optionally with a documentation page.
+//
+// A variadic set of [OptionRoute] used to configure the registered handlers, or every handler of a [ServeMux.Group] or [ServeMux.Route]:
+//   - [WithLogLevel]: Defines the minimum log level of the requests handled by the route.
+//   - [WithSummary], [WithDescription], [WithTags]: Describes the operation in the OpenAPI document.
+//   - [WithRequest], [WithResponse]: Defines the types of the request and response bodies in the OpenAPI document.
+//   - [WithOpenAPIHidden]: Hides the operation from the OpenAPI document.
 //
 // Methods for adding middleware:
 //   - [ServeMux.Use]: Appends one or more middlewares.
@@ -28,6 +35,7 @@
 // Methods for managing the routing path:
 //   - [ServeMux.Group]: Inline router manager, inheriting the middleware stack.
 //   - [ServeMux.Route]: Subrouter manager, inheriting the middleware stack.
+//   - [ServeMux.Mount]: Mounts an [http.Handler] for every method under a prefix, stripping it and inheriting the middleware stack.
 //
 // Methods for registering an http handler:
 //   - [ServeMux.Connect]: Registers a handler for the HTTP CONNECT method.
@@ -41,8 +49,14 @@
 //   - [ServeMux.Trace]: Registers a handler for the HTTP TRACE method.
 //   - [ServeMux.Method]: Registers a handler for the custom HTTP method.
 //
+// Methods for inspecting the registered handlers:
+//   - [ServeMux.Routes]: Returns the registered handlers, printable as a table with [Routes.String].
+//   - [Walk]: Calls a function for each registered handler.
+//   - [ServeMux.OpenAPI]: Generates the OpenAPI 3.1 document of the registered handlers, in the JSON or YAML format.
+//
 // # Middlewares
 //   - [MiddlewareLogging]: Logs each incoming request along with useful metadata regarding the request.
+//   - [NewMiddlewareLogging]: Creates the logging middleware, authorizing the X-Logger-Level header with [LevelVerifier].
 //   - [MiddlewareTrace]: Adds attributes to spans and metrics for telemetry purposes.
 //   - [MiddlewareRecover]: Recovers from panics, logs the panic, and responds with an HTTP status of 500 (Internal Server Error).
 package httpserver
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/http/httptest"
 	"time"
 
 	"github.com/telmoandrade/go-library/httpserver"
@@ -125,6 +126,19 @@ func ExampleServeMux_Route() {
 	// Output: true
 }
 
+func ExampleServeMux_Mount() {
+	legacy := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Println(r.Method, r.URL.Path)
+	})
+
+	mux := httpserver.NewServeMux()
+	mux.Use(middlewarePathValue)
+	mux.Mount("/legacy", legacy)
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/legacy/orders", nil))
+	// Output: POST /orders
+}
+
 func ExampleServeMux_Connect() {
 	mux := httpserver.NewServeMux()
 	mux.Connect("/pattern", handler)
@@ -184,3 +198,124 @@ func ExampleServeMux_Method() {
 	mux.Method("CUSTOM", "/pattern", handler)
 	// Output:
 }
+
+func ExampleServeMux_Routes() {
+	mux := httpserver.NewServeMux()
+	mux.Use(middlewarePathValue)
+	mux.Get("/user/{id}", handlerGetUser)
+	mux.Put("/user/{id}", handlerPutUser)
+
+	fmt.Print(mux.Routes())
+	// Output:
+	// METHOD  PATTERN     WILDCARDS  MIDDLEWARES  KIND
+	// *       /           -          1            HandlerNotFound
+	// *       /user/{id}  id         1            HandlerMethodNotAllowed
+	// GET     /user/{id}  id         1            HandlerFn
+	// PUT     /user/{id}  id         1            HandlerFn
+}
+
+func ExampleWalk() {
+	mux := httpserver.NewServeMux()
+	mux.Get("/user/{id}", handlerGetUser)
+	mux.Put("/user/{id}", handlerPutUser)
+
+	httpserver.Walk(mux, func(route httpserver.Route) error {
+		if route.Kind == httpserver.RouteKindHandlerFn {
+			fmt.Println(route.Method, route.Pattern, route.Wildcards)
+		}
+		return nil
+	})
+	// Output:
+	// GET /user/{id} [id]
+	// PUT /user/{id} [id]
+}
+
+type user struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+func ExampleServeMux_OpenAPI() {
+	mux := httpserver.NewServeMux(
+		httpserver.WithOpenAPI("/openapi", httpserver.WithOpenAPIUI("/docs")),
+	)
+	mux.Route("/users", func(muxUser httpserver.Router) {
+		muxUser.Get("/{id}", handlerGetUser,
+			httpserver.WithSummary("Get user"),
+			httpserver.WithResponse(http.StatusOK, user{}),
+			httpserver.WithResponse(http.StatusNotFound, nil),
+		)
+	}, httpserver.WithTags("users"))
+
+	doc, _ := mux.OpenAPI(httpserver.WithOpenAPITitle("Users")).YAML()
+	fmt.Print(string(doc))
+	// Output:
+	// openapi: 3.1.0
+	// info:
+	//   title: Users
+	//   version: 1.0.0
+	// paths:
+	//   /users/{id}:
+	//     get:
+	//       tags:
+	//         - users
+	//       summary: Get user
+	//       parameters:
+	//         - name: id
+	//           in: path
+	//           required: true
+	//           schema:
+	//             type: string
+	//       responses:
+	//         "200":
+	//           description: OK
+	//           content:
+	//             application/json:
+	//               schema:
+	//                 $ref: "#/components/schemas/user"
+	//         "404":
+	//           description: Not Found
+	// components:
+	//   schemas:
+	//     user:
+	//       type: object
+	//       properties:
+	//         id:
+	//           type: integer
+	//           format: int64
+	//         name:
+	//           type: string
+	//       required:
+	//         - id
+}
+
+func ExampleWithLogLevel() {
+	mux := httpserver.NewServeMux()
+	mux.Use(httpserver.MiddlewareLogging)
+
+	health := mux.Group("/health", httpserver.WithLogLevel(slog.LevelWarn))
+	health.Get("/live", handler)
+	health.Get("/ready", handler, httpserver.WithLogLevel(slog.LevelError))
+	// Output:
+}
+
+func ExampleNewMiddlewareLogging() {
+	secret := []byte("secret")
+
+	mux := httpserver.NewServeMux()
+	mux.Use(
+		httpserver.NewMiddlewareLogging(
+			httpserver.WithLevelVerifier(
+				httpserver.VerifyCIDR("10.0.0.0/8"),
+				httpserver.VerifyLevelToken(secret),
+			),
+			httpserver.WithLevelLimit(60),
+		),
+		httpserver.MiddlewareRecover,
+	)
+	mux.Get("/hello", handlerHello)
+
+	token := httpserver.NewLevelToken(secret, "debug", time.Now().Add(15*time.Minute))
+	fmt.Print(token != "")
+	// Output: true
+}
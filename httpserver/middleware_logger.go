@@ -1,10 +1,12 @@
 package httpserver
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -58,7 +60,36 @@ func sinceRound(since time.Duration) time.Duration {
 	return since
 }
 
+type (
+	middlewareLogging struct {
+		levelVerifiers []LevelVerifier
+		levelLimiter   *levelLimiter
+		rejectLimiter  *levelLimiter
+		logIDHeader    string
+		logIDGenerator logger.LogIDGenerator
+		logIDParsers   []logger.LogIDParser
+		logBuffer      bool
+	}
+
+	// OptionMiddlewareLogging is used to apply configurations to the middleware created with [NewMiddlewareLogging].
+	OptionMiddlewareLogging func(*middlewareLogging)
+)
+
 // MiddlewareLogging is a middleware that logs each incoming request along with useful metadata regarding the request.
+// It is the middleware created with [NewMiddlewareLogging] without options, so the X-Logger-Level header is ignored.
+//
+// Example:
+//
+//	mux := httpserver.NewServeMux()
+//	mux.Use(httpserver.MiddlewareLogging)   // <--<< MiddlewareLogging must come before MiddlewareRecover
+//	mux.Use(httpserver.MiddlewareRecover)
+//	mux.Get("/", handler)
+func MiddlewareLogging(next http.Handler) http.Handler {
+	return NewMiddlewareLogging()(next)
+}
+
+// NewMiddlewareLogging returns a middleware that logs each incoming request along with useful metadata regarding the request.
+// A variadic set of [OptionMiddlewareLogging] used to configure the behavior of the middleware.
 //
 // Response Status Handling:
 //   - Error: For response status < 100 and >= 500
@@ -66,37 +97,182 @@ func sinceRound(since time.Duration) time.Duration {
 //   - Info: Other response status
 //
 // Log Identifier Handling:
-//   - If the X-Logger-ID header is present in the request, its value will be used as the log identifier.
-//   - If the header is not present or if the value is invalid, a new log identifier will be generated using UUID v7.
-//   - The log identifier is then added to the context [logger.ContextLogID].
+//   - If the X-Logger-ID header is present in the request and accepted by a [logger.LogIDParser], its value will be used as the log identifier.
+//   - If the header is not present or if the value is invalid, a new log identifier will be generated using the [logger.LogIDGenerator].
+//   - The log identifier is then added to the context [logger.ContextLogID] and to the X-Logger-ID response header.
+//   - The header name, the generator and the parsers are defined by [WithLogIDHeader], [WithLogIDGenerator] and [WithLogIDParsers],
+//     by default UUID v7 is generated and any UUID is accepted.
 //
 // Log Level Handling:
-//   - If the X-Logger-Level header is present in the request, its value will be used as the minimum log level.
-//     Allowing lower priority logs at runtime.
-//   - The minimum log level is then added to the context [logger.ContextMinLevel].
+//   - If the X-Logger-Level header is present in the request and authorized by every [LevelVerifier] defined by [WithLevelVerifier],
+//     its value will be used as the minimum log level. Allowing lower or higher priority logs at runtime.
+//   - The value is any level accepted by [logger.MinLevel], including the custom levels such as trace and numeric levels such as -8.
+//   - Without any [LevelVerifier], the X-Logger-Level header is silently ignored.
+//   - Attempts rejected by a [LevelVerifier] or by [WithLevelLimit] are logged as warnings, at most 10 records per minute.
+//   - [WithLevelLimit] caps the number of authorized requests per minute.
+//   - The minimum log level is then added to the context [logger.ContextMinLevel], overriding the route level defined by [WithLogLevel].
 //
 // Important Note:
-//   - MiddlewareLogging should be positioned before any other middleware that may alter the response, such as [MiddlewareRecover].
+//   - The middleware should be positioned before any other middleware that may alter the response, such as [MiddlewareRecover].
 //   - Must be used with [logger.NewHandler] to register the log handle and allow lower priority logging at runtime.
+//   - With [WithLogBuffer], the debug records buffered by [logger.WithDebugOnError] are logged when the response status is error,
+//     otherwise they are discarded.
+//   - With a handler created with [logger.WithRedaction], only the values matching the redaction patterns, see [logger.WithRedactPatterns], are scrubbed
+//     from the request path and the user agent, their keys are not sensitive keys and nothing else masks them.
 //
 // Example:
 //
 //	mux := httpserver.NewServeMux()
-//	mux.Use(httpserver.MiddlewareLogging)   // <--<< MiddlewareLogging must come before MiddlewareRecover
+//	mux.Use(httpserver.NewMiddlewareLogging(   // <--<< must come before MiddlewareRecover
+//		httpserver.WithLevelVerifier(httpserver.VerifyLevelToken(secret)),
+//		httpserver.WithLevelLimit(60),
+//	))
 //	mux.Use(httpserver.MiddlewareRecover)
 //	mux.Get("/", handler)
-func MiddlewareLogging(next http.Handler) http.Handler {
+func NewMiddlewareLogging(opts ...OptionMiddlewareLogging) func(http.Handler) http.Handler {
+	ml := &middlewareLogging{
+		logIDHeader:    "X-Logger-ID",
+		logIDGenerator: logger.GenerateUUIDv7,
+		logIDParsers:   []logger.LogIDParser{logger.ParseUUID},
+		rejectLimiter:  &levelLimiter{limit: levelRejectLogLimit},
+	}
+
+	for _, opt := range opts {
+		opt(ml)
+	}
+
+	return ml.middleware
+}
+
+// WithLogIDHeader is an [OptionMiddlewareLogging] that defines the header name used to propagate the log identifier.
+//
+// Default:
+//   - The default header name is X-Logger-ID.
+func WithLogIDHeader(name string) OptionMiddlewareLogging {
+	return func(ml *middlewareLogging) {
+		if name != "" {
+			ml.logIDHeader = name
+		}
+	}
+}
+
+// WithLogIDGenerator is an [OptionMiddlewareLogging] that defines the [logger.LogIDGenerator] used to create new log identifiers.
+//
+// Default:
+//   - The default generator is [logger.GenerateUUIDv7].
+func WithLogIDGenerator(generator logger.LogIDGenerator) OptionMiddlewareLogging {
+	return func(ml *middlewareLogging) {
+		if generator != nil {
+			ml.logIDGenerator = generator
+		}
+	}
+}
+
+// WithLogIDParsers is an [OptionMiddlewareLogging] that defines the [logger.LogIDParser] list used to accept propagated log identifiers,
+// the first parser accepting the header value is used.
+//
+// Default:
+//   - The default parser is [logger.ParseUUID].
+func WithLogIDParsers(parsers ...logger.LogIDParser) OptionMiddlewareLogging {
+	return func(ml *middlewareLogging) {
+		ml.logIDParsers = slices.DeleteFunc(slices.Clone(parsers), func(p logger.LogIDParser) bool {
+			return p == nil
+		})
+	}
+}
+
+// WithLevelVerifier is an [OptionMiddlewareLogging] that defines the verifiers authorizing the X-Logger-Level header.
+// Every verifier must authorize the request.
+func WithLevelVerifier(verifiers ...LevelVerifier) OptionMiddlewareLogging {
+	return func(ml *middlewareLogging) {
+		for _, v := range verifiers {
+			if v != nil {
+				ml.levelVerifiers = append(ml.levelVerifiers, v)
+			}
+		}
+	}
+}
+
+// WithLevelLimit is an [OptionMiddlewareLogging] that defines the maximum number of requests per minute
+// authorized to change the minimum log level with the X-Logger-Level header.
+//
+// Default Behavior:
+//   - If the limit is less than or equal to zero, there is no limit.
+func WithLevelLimit(perMinute int) OptionMiddlewareLogging {
+	return func(ml *middlewareLogging) {
+		ml.levelLimiter = nil
+		if perMinute > 0 {
+			ml.levelLimiter = &levelLimiter{limit: perMinute}
+		}
+	}
+}
+
+// WithLogBuffer is an [OptionMiddlewareLogging] that adds to the request context the buffer used by [logger.WithDebugOnError],
+// see [logger.LogBuffer].
+//
+// Default:
+//   - The buffer is not added, so the records below the minimum log level are discarded.
+//
+// Important Note:
+//   - In a context with a buffer every level is enabled for the handlers created with [logger.WithDebugOnError],
+//     so it should be enabled only when the handler uses it.
+func WithLogBuffer(enabled bool) OptionMiddlewareLogging {
+	return func(ml *middlewareLogging) {
+		ml.logBuffer = enabled
+	}
+}
+
+func (ml *middlewareLogging) minLevel(ctx context.Context, r *http.Request) (context.Context, string) {
+	loggerLevel := r.Header.Get("X-Logger-Level")
+	if loggerLevel == "" || len(ml.levelVerifiers) == 0 {
+		return ctx, ""
+	}
+
+	ctxLevel, err := logger.MinLevel(ctx, loggerLevel)
+	if err != nil {
+		return ctx, ""
+	}
+
+	reason := ""
+	for _, v := range ml.levelVerifiers {
+		if !v(r) {
+			reason = "unauthorized"
+			break
+		}
+	}
+	if reason == "" && ml.levelLimiter != nil && !ml.levelLimiter.allow(time.Now()) {
+		reason = "limit exceeded"
+	}
+
+	if reason != "" {
+		if !ml.rejectLimiter.allow(time.Now()) {
+			return ctx, ""
+		}
+		slog.WarnContext(ctx, fmt.Sprintf("[HTTP] Logger level rejected: %s", reason),
+			slog.String("level", loggerLevel),
+			slog.String("ip", realIP(r)),
+		)
+		return ctx, ""
+	}
+
+	return ctxLevel, loggerLevel
+}
+
+func (ml *middlewareLogging) middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
 		ctx := r.Context()
 
-		ctx, u := logger.LogId(ctx, r.Header.Get("X-Logger-ID"))
-		w.Header().Add("X-Logger-ID", u.String())
+		ctx, logID := logger.LogIdWith(ctx, r.Header.Get(ml.logIDHeader), ml.logIDGenerator, ml.logIDParsers...)
+		w.Header().Add(ml.logIDHeader, logID)
+
+		if ml.logBuffer {
+			ctx = logger.LogBuffer(ctx)
+		}
 
-		loggerLevel := r.Header.Get("X-Logger-Level")
-		ctx, err := logger.MinLevel(ctx, loggerLevel)
-		if err == nil {
+		ctx, loggerLevel := ml.minLevel(ctx, r)
+		if loggerLevel != "" {
 			w.Header().Add("X-Logger-Level", loggerLevel)
 		}
 
@@ -109,7 +285,7 @@ func MiddlewareLogging(next http.Handler) http.Handler {
 		since := time.Since(start)
 		slogAny := []any{
 			slog.Group("log",
-				slog.String("id", u.String()),
+				slog.String("id", logID),
 			),
 			slog.Group("request",
 				slog.String("method", r.Method),
@@ -133,6 +309,7 @@ func MiddlewareLogging(next http.Handler) http.Handler {
 		msg := fmt.Sprintf("HTTP Response %03d %dB %v %s %s", wrw.code, wrw.bytes, sinceRound(since), r.Method, r.URL.Path)
 
 		if wrw.code < http.StatusContinue || wrw.code >= http.StatusInternalServerError {
+			_ = logger.FlushLogBuffer(ctx)
 			slog.ErrorContext(ctx, msg, slogAny...)
 		} else if wrw.code < http.StatusOK || wrw.code >= http.StatusBadRequest {
 			slog.WarnContext(ctx, msg, slogAny...)
@@ -0,0 +1,126 @@
+package httpserver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// LevelVerifier reports whether the request is authorized to change the minimum log level with the X-Logger-Level header.
+	// It is used as a parameter for the [WithLevelVerifier].
+	LevelVerifier func(r *http.Request) bool
+
+	levelLimiter struct {
+		mu     sync.Mutex
+		limit  int
+		window time.Time
+		count  int
+	}
+)
+
+// levelRejectLogLimit is the maximum number of rejected X-Logger-Level attempts logged per minute.
+const levelRejectLogLimit = 10
+
+func levelTokenSign(secret []byte, payload string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// NewLevelToken returns a token signed with HMAC-SHA256 authorizing the minimum log level until it expires.
+// The token must be sent in the X-Logger-Token header together with the X-Logger-Level header, and verified with [VerifyLevelToken].
+func NewLevelToken(secret []byte, level string, expiresAt time.Time) string {
+	payload := fmt.Sprintf("%s.%d", strings.ToLower(level), expiresAt.Unix())
+	return payload + "." + levelTokenSign(secret, payload)
+}
+
+// VerifyLevelToken returns a [LevelVerifier] that authorizes the request with a valid token created by [NewLevelToken] in the X-Logger-Token header.
+//
+// Behavior:
+//   - The token signature must match the secret.
+//   - The token level must be the same as the X-Logger-Level header.
+//   - The token must not be expired.
+func VerifyLevelToken(secret []byte) LevelVerifier {
+	return func(r *http.Request) bool {
+		token := r.Header.Get("X-Logger-Token")
+
+		i := strings.LastIndexByte(token, '.')
+		if i < 0 {
+			return false
+		}
+		payload, signature := token[:i], token[i+1:]
+		if !hmac.Equal([]byte(signature), []byte(levelTokenSign(secret, payload))) {
+			return false
+		}
+
+		level, expiresAt, ok := strings.Cut(payload, ".")
+		if !ok || !strings.EqualFold(level, r.Header.Get("X-Logger-Level")) {
+			return false
+		}
+
+		unix, err := strconv.ParseInt(expiresAt, 10, 64)
+		return err == nil && time.Now().Before(time.Unix(unix, 0))
+	}
+}
+
+// VerifyCIDR returns a [LevelVerifier] that authorizes requests whose remote address is in one of the CIDR blocks, like 10.0.0.0/8.
+// The remote address of the connection is used instead of forwarded headers, which can be set by any client.
+//
+// Important Note:
+//   - If a CIDR block is invalid, it will cause a panic.
+func VerifyCIDR(cidrs ...string) LevelVerifier {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			panic(fmt.Errorf("httpserver: invalid CIDR %q: %w", cidr, err))
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return func(r *http.Request) bool {
+		host := r.RemoteAddr
+		if h, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			host = h
+		}
+
+		addr, err := netip.ParseAddr(host)
+		if err != nil {
+			return false
+		}
+		addr = addr.Unmap()
+
+		for _, prefix := range prefixes {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (ll *levelLimiter) allow(now time.Time) bool {
+	ll.mu.Lock()
+	defer ll.mu.Unlock()
+
+	window := now.Truncate(time.Minute)
+	if !window.Equal(ll.window) {
+		ll.window = window
+		ll.count = 0
+	}
+
+	if ll.count >= ll.limit {
+		return false
+	}
+	ll.count++
+	return true
+}
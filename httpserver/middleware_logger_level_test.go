@@ -0,0 +1,117 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestVerifyLevelToken(t *testing.T) {
+	secret := []byte("secret")
+
+	type args struct {
+		token string
+		level string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "valid",
+			args: args{token: NewLevelToken(secret, "debug", time.Now().Add(time.Minute)), level: "DEBUG"},
+			want: true,
+		},
+		{
+			name: "without token",
+			args: args{level: "debug"},
+		},
+		{
+			name: "invalid format",
+			args: args{token: "invalid", level: "debug"},
+		},
+		{
+			name: "invalid secret",
+			args: args{token: NewLevelToken([]byte("other"), "debug", time.Now().Add(time.Minute)), level: "debug"},
+		},
+		{
+			name: "other level",
+			args: args{token: NewLevelToken(secret, "debug", time.Now().Add(time.Minute)), level: "info"},
+		},
+		{
+			name: "expired",
+			args: args{token: NewLevelToken(secret, "debug", time.Now().Add(-time.Minute)), level: "debug"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("X-Logger-Token", tt.args.token)
+			r.Header.Set("X-Logger-Level", tt.args.level)
+
+			if got := VerifyLevelToken(secret)(r); got != tt.want {
+				t.Errorf("VerifyLevelToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyCIDR(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		want       bool
+	}{
+		{name: "ipv4 allowed", remoteAddr: "10.1.2.3:1234", want: true},
+		{name: "ipv4 mapped allowed", remoteAddr: "[::ffff:10.1.2.3]:1234", want: true},
+		{name: "ipv6 allowed", remoteAddr: "[fd00::1]:1234", want: true},
+		{name: "without port", remoteAddr: "10.1.2.3", want: true},
+		{name: "not allowed", remoteAddr: "192.168.0.1:1234"},
+		{name: "invalid", remoteAddr: "invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			r.Header.Set("X-Forwarded-For", "10.1.2.3")
+
+			if got := VerifyCIDR("10.0.0.0/8", " fd00::/8")(r); got != tt.want {
+				t.Errorf("VerifyCIDR() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyCIDR_panic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("VerifyCIDR() did not panic")
+		}
+	}()
+	VerifyCIDR("invalid")
+}
+
+func Test_levelLimiter_allow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ll := &levelLimiter{limit: 2}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{name: "first", now: now, want: true},
+		{name: "second", now: now.Add(time.Second), want: true},
+		{name: "exceeded", now: now.Add(2 * time.Second), want: false},
+		{name: "next window", now: now.Add(time.Minute), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ll.allow(tt.now); got != tt.want {
+				t.Errorf("levelLimiter.allow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,21 +4,33 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
-	"runtime/debug"
+
+	"github.com/telmoandrade/go-library/logger"
 )
 
 // MiddlewareRecover is a middleware that recovers from panics, logs the panic, and responds with an HTTP status of 500 (Internal Server Error).
+//
+// Behavior:
+//   - The panic value is logged with [logger.Err] and the attribute recover=true, like any other error.
+//   - The type of the panic value is logged in the attribute type, like string or runtime.boundsError,
+//     a panic value that is not an error is logged as an error with its message.
+//   - The stack trace is the one of the panic, unless the panic value is an error created by [logger.Errorf] or [logger.WithStack].
 func MiddlewareRecover(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
-			if err := recover(); err != nil {
+			if rvr := recover(); rvr != nil {
 				w.WriteHeader(http.StatusInternalServerError)
 
-				slog.ErrorContext(r.Context(), "Panic recover", slog.Group("error",
+				err, ok := rvr.(error)
+				if !ok {
+					err = fmt.Errorf("%v", rvr)
+				}
+
+				slog.ErrorContext(r.Context(), "Panic recover",
 					slog.Bool("recover", true),
-					slog.String("message", fmt.Sprintf("%v", err)),
-					slog.String("stack", string(debug.Stack())),
-				))
+					slog.String("type", fmt.Sprintf("%T", rvr)),
+					logger.Err(logger.WithStack(err)),
+				)
 			}
 		}()
 		next.ServeHTTP(w, r)
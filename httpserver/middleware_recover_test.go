@@ -1,22 +1,29 @@
 package httpserver
 
 import (
-	"bytes"
-	"log"
+	"context"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/telmoandrade/go-library/logger"
+	"github.com/telmoandrade/go-library/logger/logtest"
 )
 
 func TestMiddlewareRecover(t *testing.T) {
-	var buf bytes.Buffer
-	log.SetOutput(&buf)
-
 	type args struct {
-		generatePanic bool
+		panicValue any
 	}
 	type want struct {
 		statusCode int
+		logged     bool
+		message    string
+		errType    string
+		panicType  string
+		stackFunc  string
 	}
 	tests := []struct {
 		name string
@@ -25,23 +32,40 @@ func TestMiddlewareRecover(t *testing.T) {
 	}{
 		{
 			name: "no panic",
-			args: args{generatePanic: false},
+			args: args{},
 			want: want{statusCode: http.StatusOK},
 		},
 		{
 			name: "panic",
-			args: args{generatePanic: true},
-			want: want{statusCode: http.StatusInternalServerError},
+			args: args{panicValue: "fail"},
+			want: want{statusCode: http.StatusInternalServerError, logged: true, message: "fail", errType: "*errors.errorString", panicType: "string", stackFunc: "MiddlewareRecover"},
+		},
+		{
+			name: "panic int",
+			args: args{panicValue: 42},
+			want: want{statusCode: http.StatusInternalServerError, logged: true, message: "42", errType: "*errors.errorString", panicType: "int", stackFunc: "MiddlewareRecover"},
+		},
+		{
+			name: "panic error",
+			args: args{panicValue: logger.Errorf("fail")},
+			want: want{statusCode: http.StatusInternalServerError, logged: true, message: "fail", errType: "*errors.errorString", panicType: "*logger.stackError", stackFunc: "TestMiddlewareRecover"},
+		},
+		{
+			name: "panic wrapped error",
+			args: args{panicValue: fmt.Errorf("fail: %w", context.Canceled)},
+			want: want{statusCode: http.StatusInternalServerError, logged: true, message: "fail: context canceled", errType: "*fmt.wrapError", panicType: "*fmt.wrapError", stackFunc: "MiddlewareRecover"},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			h := logtest.SetDefault(t)
+
 			r := httptest.NewRequest(http.MethodGet, "/", nil)
 			w := httptest.NewRecorder()
 
 			m := MiddlewareRecover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				if tt.args.generatePanic {
-					panic("fail")
+				if tt.args.panicValue != nil {
+					panic(tt.args.panicValue)
 				}
 				w.WriteHeader(http.StatusOK)
 			}))
@@ -51,6 +75,25 @@ func TestMiddlewareRecover(t *testing.T) {
 			if w.Code != tt.want.statusCode {
 				t.Errorf("Code() = %v, want %v", w.Code, tt.want.statusCode)
 			}
+
+			predicates := []logtest.Predicate{
+				logtest.Level(slog.LevelError),
+				logtest.Message("Panic recover"),
+				logtest.Attr("recover", true),
+				logtest.Attr("type", tt.want.panicType),
+				logtest.Attr("error.message", tt.want.message),
+				logtest.Attr("error.type", tt.want.errType),
+				func(r logtest.Record) bool {
+					v, _ := r.Attr("error.stack")
+					stack, _ := v.Any().([]string)
+					return len(stack) > 0 && strings.Contains(stack[0], tt.want.stackFunc)
+				},
+			}
+			if tt.want.logged {
+				logtest.AssertLogged(t, h, predicates...)
+			} else {
+				logtest.AssertNotLogged(t, h, predicates...)
+			}
 		})
 	}
 }
@@ -3,7 +3,6 @@ package httpserver
 import (
 	"net/http"
 
-	"github.com/google/uuid"
 	"github.com/telmoandrade/go-library/logger"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
@@ -44,10 +43,9 @@ func MiddlewareTrace(next http.Handler) http.Handler {
 		span := trace.SpanFromContext(r.Context())
 		span.SetAttributes(attr)
 
-		u, _ := r.Context().Value(logger.ContextLogID).(uuid.UUID)
-		if u != uuid.Nil {
+		if logID, _ := r.Context().Value(logger.ContextLogID).(string); logID != "" {
 			span.SetAttributes(
-				attribute.Key("log.id").String(u.String()),
+				attribute.Key("log.id").String(logID),
 			)
 		}
 
@@ -6,7 +6,6 @@ import (
 	"net/http/httptest"
 	"testing"
 
-	"github.com/google/uuid"
 	"github.com/telmoandrade/go-library/logger"
 )
 
@@ -14,19 +13,19 @@ func TestMiddlewareTrace(t *testing.T) {
 	tests := []struct {
 		name   string
 		status int
-		args   uuid.UUID
+		args   string
 		want   int
 	}{
 		{
 			name:   "without logId",
 			status: http.StatusOK,
-			args:   uuid.Nil,
+			args:   "",
 			want:   http.StatusOK,
 		},
 		{
 			name:   "with logId",
 			status: http.StatusOK,
-			args:   uuid.Must(uuid.NewV7()),
+			args:   logger.GenerateUUIDv7(),
 			want:   http.StatusOK,
 		},
 	}
@@ -0,0 +1,317 @@
+package httpserver
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type (
+	// OpenAPI generates the OpenAPI 3.1 document of the handlers registered in a [ServeMux].
+	// It is created with [ServeMux.OpenAPI].
+	OpenAPI struct {
+		mux    *serveMux
+		config *openAPIConfig
+	}
+
+	routeOperation struct {
+		method       string
+		patternRoute *patternRoute
+		config       *routeConfig
+	}
+
+	openAPIDocument struct {
+		OpenAPI    string                      `json:"openapi"`
+		Info       openAPIInfo                 `json:"info"`
+		Servers    []openAPIServer             `json:"servers,omitempty"`
+		Paths      map[string]*openAPIPathItem `json:"paths"`
+		Components *openAPIComponents          `json:"components,omitempty"`
+	}
+
+	openAPIInfo struct {
+		Title       string `json:"title"`
+		Description string `json:"description,omitempty"`
+		Version     string `json:"version"`
+	}
+
+	openAPIServer struct {
+		URL string `json:"url"`
+	}
+
+	openAPIPathItem struct {
+		Get     *openAPIOperation `json:"get,omitempty"`
+		Put     *openAPIOperation `json:"put,omitempty"`
+		Post    *openAPIOperation `json:"post,omitempty"`
+		Delete  *openAPIOperation `json:"delete,omitempty"`
+		Options *openAPIOperation `json:"options,omitempty"`
+		Head    *openAPIOperation `json:"head,omitempty"`
+		Patch   *openAPIOperation `json:"patch,omitempty"`
+		Trace   *openAPIOperation `json:"trace,omitempty"`
+	}
+
+	openAPIOperation struct {
+		Tags        []string                    `json:"tags,omitempty"`
+		Summary     string                      `json:"summary,omitempty"`
+		Description string                      `json:"description,omitempty"`
+		Parameters  []openAPIParameter          `json:"parameters,omitempty"`
+		RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+		Responses   map[string]*openAPIResponse `json:"responses,omitempty"`
+	}
+
+	openAPIParameter struct {
+		Name     string      `json:"name"`
+		In       string      `json:"in"`
+		Required bool        `json:"required"`
+		Schema   *jsonSchema `json:"schema"`
+	}
+
+	openAPIRequestBody struct {
+		Required bool                        `json:"required"`
+		Content  map[string]openAPIMediaType `json:"content"`
+	}
+
+	openAPIResponse struct {
+		Description string                      `json:"description"`
+		Content     map[string]openAPIMediaType `json:"content,omitempty"`
+	}
+
+	openAPIMediaType struct {
+		Schema *jsonSchema `json:"schema"`
+	}
+
+	openAPIComponents struct {
+		Schemas map[string]*jsonSchema `json:"schemas"`
+	}
+)
+
+//go:embed openapi.html
+var openAPIHTML string
+
+var openAPITemplate = template.Must(template.New("openapi").Parse(openAPIHTML))
+
+// OpenAPI returns the generator of the OpenAPI 3.1 document of the handlers registered in the [ServeMux].
+// A variadic set of [OptionOpenAPI] used to configure the document.
+//
+// Behavior:
+//   - Each handler registered with the [Handle] methods is an operation, except the ones with [WithOpenAPIHidden].
+//   - The wildcards of the pattern, like {id} and {path...}, are path parameters of type string.
+//   - The operation is described by the [OptionRoute] [WithSummary], [WithDescription], [WithTags], [WithRequest] and [WithResponse].
+//   - The schemas of the request and response types are generated by reflection, following the encoding/json rules.
+//   - The types implementing [json.Marshaler] accept any value, the types implementing [encoding.TextMarshaler] are strings.
+//   - The named structs are added to the components of the document and referenced by name.
+//   - The struct fields without the omitempty or omitzero options of the json tag are required.
+//
+// Important Note:
+//   - The host of the pattern is not part of the document, the operations of different hosts share the same paths.
+//   - The operations of different hosts with the same method and path in the document, including the patterns ending
+//     with {$} and with a trailing slash, cause an error in [OpenAPI.JSON] and [OpenAPI.YAML].
+//   - The CONNECT and the custom methods are not part of the document, they are not supported by OpenAPI 3.1.
+//   - The document is generated on each call, including the handlers registered until then.
+func (mux *serveMux) OpenAPI(opts ...OptionOpenAPI) *OpenAPI {
+	return &OpenAPI{
+		mux:    mux,
+		config: newOpenAPIConfig("", opts),
+	}
+}
+
+// JSON returns the OpenAPI document in the JSON format.
+func (o *OpenAPI) JSON() ([]byte, error) {
+	doc, err := o.document()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// YAML returns the OpenAPI document in the YAML format.
+func (o *OpenAPI) YAML() ([]byte, error) {
+	doc, err := o.document()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return jsonToYAML(data)
+}
+
+func (o *OpenAPI) document() (*openAPIDocument, error) {
+	doc := &openAPIDocument{
+		OpenAPI: "3.1.0",
+		Info: openAPIInfo{
+			Title:       o.config.title,
+			Description: o.config.description,
+			Version:     o.config.version,
+		},
+		Paths: map[string]*openAPIPathItem{},
+	}
+	for _, url := range o.config.servers {
+		doc.Servers = append(doc.Servers, openAPIServer{URL: url})
+	}
+
+	g := newSchemaGenerator()
+	patterns := map[string]string{}
+	for _, op := range *o.mux.operations {
+		if op.config.hidden {
+			continue
+		}
+
+		path := openAPIPath(op.patternRoute)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &openAPIPathItem{}
+		}
+
+		operation := item.operation(op.method)
+		if operation == nil {
+			continue
+		}
+		if pattern, ok := patterns[op.method+" "+path]; ok {
+			return nil, fmt.Errorf("httpserver: operation %s %s of pattern %s conflicts with pattern %s", op.method, path, op.patternRoute, pattern)
+		}
+		patterns[op.method+" "+path] = op.patternRoute.String()
+		*operation = op.operation(g)
+		doc.Paths[path] = item
+	}
+
+	if len(g.schemas) > 0 {
+		doc.Components = &openAPIComponents{Schemas: g.schemas}
+	}
+
+	return doc, nil
+}
+
+// openAPIPath returns the path of the pattern without the host, with the wildcards as path parameters.
+func openAPIPath(pr *patternRoute) string {
+	segs := strings.Split(pr.pattern, "/")
+	for i, seg := range segs {
+		if seg == "{$}" {
+			segs[i] = ""
+		} else if name, ok := strings.CutSuffix(seg, "...}"); ok {
+			segs[i] = name + "}"
+		}
+	}
+	return strings.Join(segs, "/")
+}
+
+// operation returns the field of the method, or nil when the method is not supported by OpenAPI.
+func (item *openAPIPathItem) operation(method string) **openAPIOperation {
+	switch method {
+	case http.MethodGet:
+		return &item.Get
+	case http.MethodPut:
+		return &item.Put
+	case http.MethodPost:
+		return &item.Post
+	case http.MethodDelete:
+		return &item.Delete
+	case http.MethodOptions:
+		return &item.Options
+	case http.MethodHead:
+		return &item.Head
+	case http.MethodPatch:
+		return &item.Patch
+	case http.MethodTrace:
+		return &item.Trace
+	default:
+		return nil
+	}
+}
+
+func (op *routeOperation) operation(g *schemaGenerator) *openAPIOperation {
+	operation := &openAPIOperation{
+		Tags:        op.config.tags,
+		Summary:     op.config.summary,
+		Description: op.config.description,
+	}
+
+	for _, name := range op.patternRoute.wildcardNames() {
+		operation.Parameters = append(operation.Parameters, openAPIParameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &jsonSchema{Type: "string"},
+		})
+	}
+
+	if op.config.request != nil {
+		operation.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content:  openAPIContent(g, op.config.request),
+		}
+	}
+
+	if len(op.config.responses) > 0 {
+		operation.Responses = map[string]*openAPIResponse{}
+	}
+	for status, t := range op.config.responses {
+		description := http.StatusText(status)
+		if description == "" {
+			description = "Status " + strconv.Itoa(status)
+		}
+
+		resp := &openAPIResponse{Description: description}
+		if t != nil {
+			resp.Content = openAPIContent(g, t)
+		}
+		operation.Responses[strconv.Itoa(status)] = resp
+	}
+
+	return operation
+}
+
+func openAPIContent(g *schemaGenerator, t reflect.Type) map[string]openAPIMediaType {
+	return map[string]openAPIMediaType{
+		"application/json": {Schema: g.schema(t)},
+	}
+}
+
+func (o *OpenAPI) serveJSON(w http.ResponseWriter, r *http.Request) {
+	o.serve(w, "application/json", o.JSON)
+}
+
+func (o *OpenAPI) serveYAML(w http.ResponseWriter, r *http.Request) {
+	o.serve(w, "application/yaml", o.YAML)
+}
+
+func (o *OpenAPI) serve(w http.ResponseWriter, contentType string, fn func() ([]byte, error)) {
+	data, err := fn()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(data)
+}
+
+func (o *OpenAPI) serveUI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = openAPITemplate.Execute(w, struct {
+		Title   string
+		SpecURL string
+	}{
+		Title:   o.config.title,
+		SpecURL: relativeURL(newPatternRoute(o.config.uiPattern).pattern, newPatternRoute(o.config.pattern).pattern+".json"),
+	})
+}
+
+// relativeURL returns the URL of the target path relative to the page path, like ../openapi.json,
+// so the page works when the [ServeMux] is mounted under a prefix or behind [http.StripPrefix].
+func relativeURL(page, target string) string {
+	pageDir := strings.Split(page[:strings.LastIndexByte(page, '/')], "/")
+	targetSegs := strings.Split(target, "/")
+
+	common := 0
+	for common < len(pageDir) && common < len(targetSegs)-1 && pageDir[common] == targetSegs[common] {
+		common++
+	}
+
+	return strings.Repeat("../", len(pageDir)-common) + strings.Join(targetSegs[common:], "/")
+}
@@ -0,0 +1,295 @@
+package httpserver
+
+import (
+	"bytes"
+	"cmp"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	jsonSchema struct {
+		Ref                  string           `json:"$ref,omitempty"`
+		Type                 string           `json:"type,omitempty"`
+		Format               string           `json:"format,omitempty"`
+		Items                *jsonSchema      `json:"items,omitempty"`
+		Properties           schemaProperties `json:"properties,omitempty"`
+		Required             []string         `json:"required,omitempty"`
+		AdditionalProperties *jsonSchema      `json:"additionalProperties,omitempty"`
+	}
+
+	schemaProperty struct {
+		name   string
+		schema *jsonSchema
+	}
+
+	// schemaProperties keeps the properties in the order of the struct fields.
+	schemaProperties []schemaProperty
+
+	schemaGenerator struct {
+		schemas map[string]*jsonSchema
+		names   map[reflect.Type]string
+	}
+
+	// schemaField is a field of the JSON object of a struct, including the fields promoted from the embedded structs.
+	schemaField struct {
+		name   string
+		index  []int
+		tagged bool
+		typ    reflect.Type
+		opts   string
+	}
+)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	marshalerType     = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	invalidSchemaName = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+)
+
+func (sp schemaProperties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, p := range sp {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(p.name)
+		buf.Write(name)
+		buf.WriteByte(':')
+
+		schema, err := json.Marshal(p.schema)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(schema)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		schemas: map[string]*jsonSchema{},
+		names:   map[reflect.Type]string{},
+	}
+}
+
+// schema returns the schema of the type, the named structs are added to the components and referenced.
+// The types implementing [json.Marshaler] have any value, and the types implementing [encoding.TextMarshaler] are strings,
+// like the encoding/json package.
+func (g *schemaGenerator) schema(t reflect.Type) *jsonSchema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	case implements(t, marshalerType):
+		return &jsonSchema{}
+	case implements(t, textMarshalerType):
+		return &jsonSchema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &jsonSchema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return &jsonSchema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &jsonSchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &jsonSchema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return &jsonSchema{Type: "string", Format: "byte"}
+		}
+		return &jsonSchema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &jsonSchema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return &jsonSchema{Ref: "#/components/schemas/" + g.componentName(t)}
+	default:
+		return &jsonSchema{}
+	}
+}
+
+// componentName returns the name of the struct in the components, generating its schema on the first use.
+func (g *schemaGenerator) componentName(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	base := invalidSchemaName.ReplaceAllString(t.Name(), "_")
+	name := base
+	for i := 2; g.schemas[name] != nil; i++ {
+		name = base + strconv.Itoa(i)
+	}
+
+	g.names[t] = name
+	g.schemas[name] = &jsonSchema{}
+	*g.schemas[name] = *g.structSchema(t)
+
+	return name
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) *jsonSchema {
+	s := &jsonSchema{Type: "object"}
+	g.addFields(s, t)
+	return s
+}
+
+// implements reports whether the type or its pointer implements the interface.
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+// addFields adds the fields of the JSON object to the properties, using the name of the json tag.
+func (g *schemaGenerator) addFields(s *jsonSchema, t reflect.Type) {
+	for _, f := range jsonFields(t) {
+		schema := g.schema(f.typ)
+		if hasTagOption(f.opts, "string") {
+			schema = &jsonSchema{Type: "string"}
+		}
+		s.Properties = append(s.Properties, schemaProperty{name: f.name, schema: schema})
+
+		if !hasTagOption(f.opts, "omitempty") && !hasTagOption(f.opts, "omitzero") {
+			s.Required = append(s.Required, f.name)
+		}
+	}
+}
+
+// jsonFields returns the fields of the JSON object of the struct in the order of the struct fields,
+// flattening the embedded structs with the rules of the encoding/json package:
+//   - The exported fields of the embedded structs without a name in the json tag are promoted.
+//   - A field with the same name of a less nested field is hidden.
+//   - Between the fields with the same name at the same depth, the field with the json tag is used,
+//     otherwise all of them are ignored.
+func jsonFields(t reflect.Type) []schemaField {
+	var fields []schemaField
+
+	current := []schemaField{}
+	next := []schemaField{{typ: t}}
+	var count, nextCount map[reflect.Type]int
+	visited := map[reflect.Type]bool{}
+
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[reflect.Type]int{}
+
+		for _, f := range current {
+			if visited[f.typ] {
+				continue
+			}
+			visited[f.typ] = true
+
+			for i := range f.typ.NumField() {
+				sf := f.typ.Field(i)
+				if sf.Anonymous {
+					ft := sf.Type
+					if ft.Kind() == reflect.Pointer {
+						ft = ft.Elem()
+					}
+					if !sf.IsExported() && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+
+				index := append(slices.Clone(f.index), i)
+
+				ft := sf.Type
+				if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+
+				if name != "" || !sf.Anonymous || ft.Kind() != reflect.Struct {
+					field := schemaField{name: name, index: index, tagged: name != "", typ: sf.Type, opts: opts}
+					if field.name == "" {
+						field.name = sf.Name
+					}
+					fields = append(fields, field)
+					if count[f.typ] > 1 {
+						// The struct is embedded more than once at the same depth, its fields annihilate each other.
+						fields = append(fields, field)
+					}
+					continue
+				}
+
+				nextCount[ft]++
+				if nextCount[ft] == 1 {
+					next = append(next, schemaField{name: ft.Name(), index: index, typ: ft})
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(fields, func(a, b schemaField) int {
+		if c := strings.Compare(a.name, b.name); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(len(a.index), len(b.index)); c != 0 {
+			return c
+		}
+		if a.tagged != b.tagged {
+			if a.tagged {
+				return -1
+			}
+			return 1
+		}
+		return slices.Compare(a.index, b.index)
+	})
+
+	// The dominant field of each name is the least nested one, and the tagged one at the same depth.
+	dominant := fields[:0]
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+
+		group := fields[i:j]
+		if len(group) == 1 || len(group[0].index) < len(group[1].index) || (group[0].tagged && !group[1].tagged) {
+			dominant = append(dominant, group[0])
+		}
+		i = j
+	}
+
+	slices.SortFunc(dominant, func(a, b schemaField) int {
+		return slices.Compare(a.index, b.index)
+	})
+	return dominant
+}
+
+func hasTagOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,251 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type (
+	schemaAddress struct {
+		Street string `json:"street"`
+	}
+
+	schemaBase struct {
+		ID int64 `json:"id"`
+	}
+
+	schemaUser struct {
+		schemaBase
+		Name     string            `json:"name,omitempty"`
+		Age      int               `json:"age,string"`
+		Created  time.Time         `json:"created,omitzero"`
+		Address  *schemaAddress    `json:"address,omitempty"`
+		Friends  []schemaUser      `json:"friends,omitempty"`
+		Labels   map[string]string `json:"labels,omitempty"`
+		Raw      json.RawMessage   `json:"raw,omitempty"`
+		Ignored  string            `json:"-"`
+		Untagged bool
+		private  string
+	}
+
+	schemaPage[T any] struct {
+		Items []T `json:"items"`
+	}
+
+	schemaMoney struct {
+		Cents int64
+	}
+
+	schemaStatus int
+
+	schemaName struct {
+		Name  string `json:"name"`
+		Label string
+		Text  string
+	}
+
+	schemaTitle struct {
+		Text  string
+		Title string `json:"Label"`
+	}
+
+	schemaShadow struct {
+		schemaName
+		schemaTitle
+		Text string
+	}
+
+	schemaNameA struct {
+		schemaName
+	}
+
+	schemaNameB struct {
+		schemaName
+	}
+
+	schemaTwice struct {
+		schemaNameA
+		schemaNameB
+		Value string `json:"value"`
+	}
+
+	schemaNode struct {
+		*schemaNode
+		Value string `json:"value"`
+	}
+)
+
+func (m *schemaMoney) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Cents)
+}
+
+func (s schemaStatus) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(int(s))), nil
+}
+
+func Test_schemaGenerator_schema(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        any
+		want        string
+		wantSchemas string
+	}{
+		{name: "bool", args: true, want: `{"type":"boolean"}`},
+		{name: "int32", args: int16(1), want: `{"type":"integer","format":"int32"}`},
+		{name: "int64", args: uint(1), want: `{"type":"integer","format":"int64"}`},
+		{name: "float", args: float32(1), want: `{"type":"number","format":"float"}`},
+		{name: "double", args: 1.0, want: `{"type":"number","format":"double"}`},
+		{name: "string", args: "", want: `{"type":"string"}`},
+		{name: "pointer", args: new(string), want: `{"type":"string"}`},
+		{name: "time", args: time.Time{}, want: `{"type":"string","format":"date-time"}`},
+		{name: "bytes", args: []byte{}, want: `{"type":"string","format":"byte"}`},
+		{name: "array", args: [2]byte{}, want: `{"type":"array","items":{"type":"integer","format":"int32"}}`},
+		{name: "slice", args: []int{}, want: `{"type":"array","items":{"type":"integer","format":"int64"}}`},
+		{name: "map", args: map[string]bool{}, want: `{"type":"object","additionalProperties":{"type":"boolean"}}`},
+		{name: "any", args: []any{}, want: `{"type":"array","items":{}}`},
+		{name: "unsupported", args: make(chan int), want: `{}`},
+		{name: "raw message", args: json.RawMessage{}, want: `{}`},
+		{name: "json marshaler", args: schemaMoney{}, want: `{}`},
+		{name: "text marshaler", args: schemaStatus(1), want: `{"type":"string"}`},
+		{name: "ip", args: net.IP{}, want: `{"type":"string"}`},
+		{name: "addr", args: netip.Addr{}, want: `{"type":"string"}`},
+		{name: "text marshaler map", args: map[string]netip.Addr{}, want: `{"type":"object","additionalProperties":{"type":"string"}}`},
+		{
+			name: "anonymous struct",
+			args: struct {
+				A string `json:"a"`
+			}{},
+			want: `{"type":"object","properties":{"a":{"type":"string"}},"required":["a"]}`,
+		},
+		{
+			name: "named struct",
+			args: schemaUser{},
+			want: `{"$ref":"#/components/schemas/schemaUser"}`,
+			wantSchemas: `{"schemaAddress":{"type":"object","properties":{"street":{"type":"string"}},"required":["street"]},` +
+				`"schemaUser":{"type":"object","properties":{"id":{"type":"integer","format":"int64"},"name":{"type":"string"},"age":{"type":"string"},` +
+				`"created":{"type":"string","format":"date-time"},"address":{"$ref":"#/components/schemas/schemaAddress"},` +
+				`"friends":{"type":"array","items":{"$ref":"#/components/schemas/schemaUser"}},` +
+				`"labels":{"type":"object","additionalProperties":{"type":"string"}},"raw":{},"Untagged":{"type":"boolean"}},` +
+				`"required":["id","age","Untagged"]}}`,
+		},
+		{
+			name:        "generic struct",
+			args:        schemaPage[int]{},
+			want:        `{"$ref":"#/components/schemas/schemaPage_int_"}`,
+			wantSchemas: `{"schemaPage_int_":{"type":"object","properties":{"items":{"type":"array","items":{"type":"integer","format":"int64"}}},"required":["items"]}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newSchemaGenerator()
+			got, _ := json.Marshal(g.schema(reflect.TypeOf(tt.args)))
+			if string(got) != tt.want {
+				t.Errorf("schemaGenerator.schema() = %s, want %s", got, tt.want)
+			}
+
+			gotSchemas, _ := json.Marshal(g.schemas)
+			if tt.wantSchemas == "" {
+				tt.wantSchemas = "{}"
+			}
+			if string(gotSchemas) != tt.wantSchemas {
+				t.Errorf("schemaGenerator.schemas = %s, want %s", gotSchemas, tt.wantSchemas)
+			}
+		})
+	}
+}
+
+func Test_jsonFields(t *testing.T) {
+	tests := []struct {
+		name string
+		args reflect.Type
+		want []string
+	}{
+		{name: "promoted", args: reflect.TypeFor[schemaUser](), want: []string{"id", "name", "age", "created", "address", "friends", "labels", "raw", "Untagged"}},
+		{name: "less nested and tagged fields are dominant", args: reflect.TypeFor[schemaShadow](), want: []string{"name", "Label", "Text"}},
+		{
+			name: "same depth without tag are ignored",
+			args: reflect.TypeFor[struct {
+				schemaName
+				schemaTitle
+			}](),
+			want: []string{"name", "Label"},
+		},
+		{name: "embedded twice at the same depth", args: reflect.TypeFor[schemaTwice](), want: []string{"value"}},
+		{name: "recursive embedded pointer", args: reflect.TypeFor[schemaNode](), want: []string{"value"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, f := range jsonFields(tt.args) {
+				got = append(got, f.name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("jsonFields() = %v, want %v", got, tt.want)
+			}
+
+			// The fields are the same encoded by the encoding/json package.
+			if tt.args.Kind() == reflect.Struct && tt.args != reflect.TypeFor[schemaUser]() {
+				data, err := json.Marshal(reflect.New(tt.args).Interface())
+				if err != nil {
+					t.Fatal(err)
+				}
+				var object map[string]any
+				if err := json.Unmarshal(data, &object); err != nil {
+					t.Fatal(err)
+				}
+				if len(object) != len(tt.want) {
+					t.Errorf("encoding/json fields = %v, want %v", object, tt.want)
+				}
+				for _, name := range tt.want {
+					if _, ok := object[name]; !ok {
+						t.Errorf("encoding/json fields = %v, want %v", object, name)
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_schemaGenerator_componentName(t *testing.T) {
+	type schemaAddress struct {
+		City string `json:"city"`
+	}
+
+	g := newSchemaGenerator()
+	tests := []struct {
+		name string
+		args reflect.Type
+		want string
+	}{
+		{name: "first", args: reflect.TypeFor[schemaUser]().Field(4).Type.Elem(), want: "schemaAddress"},
+		{name: "same name of another type", args: reflect.TypeFor[schemaAddress](), want: "schemaAddress2"},
+		{name: "already named", args: reflect.TypeFor[schemaUser]().Field(4).Type.Elem(), want: "schemaAddress"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := g.componentName(tt.args); got != tt.want {
+				t.Errorf("schemaGenerator.componentName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_schemaProperties_MarshalJSON(t *testing.T) {
+	sp := schemaProperties{
+		{name: "b", schema: &jsonSchema{Type: "string"}},
+		{name: "a", schema: &jsonSchema{}},
+	}
+
+	got, err := json.Marshal(sp)
+	if err != nil {
+		t.Fatalf("schemaProperties.MarshalJSON() error = %v", err)
+	}
+	if want := `{"b":{"type":"string"},"a":{}}`; string(got) != want {
+		t.Errorf("schemaProperties.MarshalJSON() = %s, want %s", got, want)
+	}
+}
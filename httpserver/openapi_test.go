@@ -0,0 +1,340 @@
+package httpserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func Test_serveMux_OpenAPI(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []OptionOpenAPI
+		register func(mux ServeMux)
+		want     string
+	}{
+		{
+			name:     "empty",
+			register: func(mux ServeMux) {},
+			want:     `{"openapi":"3.1.0","info":{"title":"API","version":"1.0.0"},"paths":{}}`,
+		},
+		{
+			name: "info",
+			opts: []OptionOpenAPI{
+				WithOpenAPITitle("Users"),
+				WithOpenAPIDescription("Users API"),
+				WithOpenAPIVersion("2.0.0"),
+				WithOpenAPIServers("https://api.test.com", ""),
+			},
+			register: func(mux ServeMux) {},
+			want:     `{"openapi":"3.1.0","info":{"title":"Users","description":"Users API","version":"2.0.0"},"servers":[{"url":"https://api.test.com"}],"paths":{}}`,
+		},
+		{
+			name: "operations",
+			register: func(mux ServeMux) {
+				mux.Route("/users", func(sub Router) {
+					sub.Get("/{id}", handlerId(), WithSummary("Get user"), WithDescription("Returns the user"),
+						WithResponse(http.StatusOK, schemaAddress{}), WithResponse(http.StatusNotFound, nil), WithResponse(299, nil))
+					sub.Put("/{id}", handlerId(), WithTags("write"), WithRequest(&schemaAddress{}), WithResponse(http.StatusNoContent, nil))
+				}, WithTags("users"))
+			},
+			want: `{"openapi":"3.1.0","info":{"title":"API","version":"1.0.0"},"paths":{"/users/{id}":{` +
+				`"get":{"tags":["users"],"summary":"Get user","description":"Returns the user",` +
+				`"parameters":[{"name":"id","in":"path","required":true,"schema":{"type":"string"}}],` +
+				`"responses":{"200":{"description":"OK","content":{"application/json":{"schema":{"$ref":"#/components/schemas/schemaAddress"}}}},` +
+				`"299":{"description":"Status 299"},"404":{"description":"Not Found"}}},` +
+				`"put":{"tags":["users","write"],"parameters":[{"name":"id","in":"path","required":true,"schema":{"type":"string"}}],` +
+				`"requestBody":{"required":true,"content":{"application/json":{"schema":{"$ref":"#/components/schemas/schemaAddress"}}}},` +
+				`"responses":{"204":{"description":"No Content"}}}}},` +
+				`"components":{"schemas":{"schemaAddress":{"type":"object","properties":{"street":{"type":"string"}},"required":["street"]}}}}`,
+		},
+		{
+			name: "paths",
+			register: func(mux ServeMux) {
+				mux.Get("www.test.com/files/{dir}/{path...}", handlerId())
+				mux.Delete("/items/{$}", handlerId())
+				mux.Connect("/tunnel", handlerId())
+				mux.Method("purge", "/cache", handlerId())
+				mux.Get("/health", handlerId(), WithOpenAPIHidden())
+			},
+			want: `{"openapi":"3.1.0","info":{"title":"API","version":"1.0.0"},"paths":{` +
+				`"/files/{dir}/{path}":{"get":{"parameters":[{"name":"dir","in":"path","required":true,"schema":{"type":"string"}},` +
+				`{"name":"path","in":"path","required":true,"schema":{"type":"string"}}]}},` +
+				`"/items/":{"delete":{}}}}`,
+		},
+		{
+			name: "methods",
+			register: func(mux ServeMux) {
+				for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+					http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace} {
+					mux.Method(method, "/items", handlerId())
+				}
+			},
+			want: `{"openapi":"3.1.0","info":{"title":"API","version":"1.0.0"},"paths":{"/items":{` +
+				`"get":{},"put":{},"post":{},"delete":{},"options":{},"head":{},"patch":{},"trace":{}}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := NewServeMux()
+			tt.register(mux)
+
+			got, err := mux.OpenAPI(tt.opts...).JSON()
+			if err != nil {
+				t.Fatalf("OpenAPI.JSON() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			json.Compact(&buf, got)
+			if buf.String() != tt.want {
+				t.Errorf("OpenAPI.JSON() = %s, want %s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenAPI_YAML(t *testing.T) {
+	mux := NewServeMux()
+	mux.Get("/users/{id}", handlerId(), WithSummary("Get user"))
+
+	got, err := mux.OpenAPI().YAML()
+	if err != nil {
+		t.Fatalf("OpenAPI.YAML() error = %v", err)
+	}
+
+	want := "openapi: 3.1.0\n" +
+		"info:\n" +
+		"  title: API\n" +
+		"  version: 1.0.0\n" +
+		"paths:\n" +
+		"  /users/{id}:\n" +
+		"    get:\n" +
+		"      summary: Get user\n" +
+		"      parameters:\n" +
+		"        - name: id\n" +
+		"          in: path\n" +
+		"          required: true\n" +
+		"          schema:\n" +
+		"            type: string\n"
+	if string(got) != want {
+		t.Errorf("OpenAPI.YAML() = %q, want %q", got, want)
+	}
+}
+
+func TestOpenAPI_serve(t *testing.T) {
+	mux := NewServeMux(WithOpenAPI("api.test.com/spec/", WithOpenAPITitle("Users <API>"), WithOpenAPIUI("api.test.com/docs")))
+	mux.Get("api.test.com/users", handlerId())
+
+	tests := []struct {
+		name            string
+		path            string
+		wantStatus      int
+		wantContentType string
+		wantBody        []string
+	}{
+		{
+			name:            "json",
+			path:            "/spec.json",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantBody:        []string{`"openapi": "3.1.0"`, `"/users": {`},
+		},
+		{
+			name:            "yaml",
+			path:            "/spec.yaml",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/yaml",
+			wantBody:        []string{"openapi: 3.1.0\n", "  /users:\n"},
+		},
+		{
+			name:            "ui",
+			path:            "/docs",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/html; charset=utf-8",
+			wantBody:        []string{"<title>Users &lt;API&gt;</title>", `<a href="spec.json">`, `var specURL = "spec.json";`},
+		},
+		{
+			name:       "not found",
+			path:       "/openapi.json",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			r.Host = "api.test.com"
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Content-Type"); tt.wantContentType != "" && got != tt.wantContentType {
+				t.Errorf("Content-Type = %v, want %v", got, tt.wantContentType)
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Errorf("body = %s, want contains %s", w.Body.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenAPI_serveUI_mounted(t *testing.T) {
+	api := NewServeMux(WithOpenAPI("/openapi", WithOpenAPIUI("/docs")))
+	api.Get("/users", handlerId())
+
+	mounted := NewServeMux()
+	mounted.Mount("/api", api)
+
+	stripped := http.NewServeMux()
+	stripped.Handle("/api/", http.StripPrefix("/api", api))
+
+	tests := []struct {
+		name    string
+		handler http.Handler
+	}{
+		{name: "mount", handler: mounted},
+		{name: "strip prefix", handler: stripped},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/docs", nil)
+			w := httptest.NewRecorder()
+			tt.handler.ServeHTTP(w, r)
+
+			_, rest, _ := strings.Cut(w.Body.String(), `var specURL = "`)
+			specURL, _, _ := strings.Cut(rest, `"`)
+			ref, err := url.Parse(specURL)
+			if err != nil || specURL == "" {
+				t.Fatalf("spec URL %q error = %v", specURL, err)
+			}
+
+			spec := r.URL.ResolveReference(ref)
+			w = httptest.NewRecorder()
+			tt.handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, spec.String(), nil))
+			if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"/users"`) {
+				t.Errorf("spec %s status = %v, body = %s", spec, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestOpenAPI_serve_error(t *testing.T) {
+	o := NewServeMux().OpenAPI()
+	w := httptest.NewRecorder()
+
+	o.serve(w, "application/json", func() ([]byte, error) { return nil, errors.New("failed") })
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %v, want %v", w.Code, http.StatusInternalServerError)
+	}
+	if got := w.Body.String(); got != "failed\n" {
+		t.Errorf("body = %q, want %q", got, "failed\n")
+	}
+}
+
+func TestOpenAPI_conflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		register func(mux ServeMux)
+		wantErr  string
+	}{
+		{
+			name: "hosts",
+			register: func(mux ServeMux) {
+				mux.Get("api.test.com/users", handlerId())
+				mux.Get("admin.test.com/users", handlerId())
+			},
+			wantErr: "httpserver: operation GET /users of pattern admin.test.com/users conflicts with pattern api.test.com/users",
+		},
+		{
+			name: "exact match and trailing slash",
+			register: func(mux ServeMux) {
+				mux.Get("api.test.com/users/{$}", handlerId())
+				mux.Get("admin.test.com/users/", handlerId())
+			},
+			wantErr: "httpserver: operation GET /users/ of pattern admin.test.com/users/ conflicts with pattern api.test.com/users/{$}",
+		},
+		{
+			name: "different methods",
+			register: func(mux ServeMux) {
+				mux.Get("api.test.com/users", handlerId())
+				mux.Post("admin.test.com/users", handlerId())
+			},
+		},
+		{
+			name: "hidden",
+			register: func(mux ServeMux) {
+				mux.Get("api.test.com/users", handlerId())
+				mux.Get("admin.test.com/users", handlerId(), WithOpenAPIHidden())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := NewServeMux()
+			tt.register(mux)
+
+			for _, generate := range []func() ([]byte, error){mux.OpenAPI().JSON, mux.OpenAPI().YAML} {
+				_, err := generate()
+				if tt.wantErr == "" && err != nil {
+					t.Errorf("OpenAPI error = %v, want nil", err)
+				} else if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+					t.Errorf("OpenAPI error = %v, want %v", err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func Test_relativeURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		page   string
+		target string
+		want   string
+	}{
+		{name: "same directory", page: "/docs", target: "/openapi.json", want: "openapi.json"},
+		{name: "page in directory", page: "/api/docs", target: "/openapi.json", want: "../openapi.json"},
+		{name: "page with slash", page: "/docs/", target: "/openapi.json", want: "../openapi.json"},
+		{name: "target in directory", page: "/docs", target: "/api/v1/openapi.json", want: "api/v1/openapi.json"},
+		{name: "common directory", page: "/api/v1/docs", target: "/api/v2/openapi.json", want: "../v2/openapi.json"},
+		{name: "exact match", page: "/docs/{$}", target: "/docs/openapi.json", want: "openapi.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := relativeURL(tt.page, tt.target); got != tt.want {
+				t.Errorf("relativeURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_openAPIPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		want    string
+	}{
+		{name: "root", pattern: "/", want: "/"},
+		{name: "host", pattern: "host/users", want: "/users"},
+		{name: "wildcard", pattern: "/users/{id}", want: "/users/{id}"},
+		{name: "multiple segments", pattern: "/files/{path...}", want: "/files/{path}"},
+		{name: "exact match", pattern: "/users/{$}", want: "/users/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := openAPIPath(newPatternRoute(tt.pattern)); got != tt.want {
+				t.Errorf("openAPIPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package httpserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type (
+	// yamlNode is a JSON value keeping the order of the object keys.
+	yamlNode struct {
+		delim  json.Delim
+		scalar string
+		keys   []string
+		values []*yamlNode
+	}
+)
+
+var (
+	yamlPlain  = regexp.MustCompile(`^[A-Za-z0-9_/$.][A-Za-z0-9 _./{}$()+-]*$`)
+	yamlNumber = regexp.MustCompile(`^[0-9][0-9a-fA-FxXoObB_]*$`)
+)
+
+// jsonToYAML converts the JSON document to YAML, keeping the order of the object keys.
+func jsonToYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	n, err := decodeYAMLNode(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	n.write(&buf, 0)
+	return buf.Bytes(), nil
+}
+
+func decodeYAMLNode(dec *json.Decoder) (*yamlNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := tok.(type) {
+	case json.Delim:
+		n := &yamlNode{delim: v}
+		for dec.More() {
+			if v == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				n.keys = append(n.keys, key.(string))
+			}
+
+			value, err := decodeYAMLNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			n.values = append(n.values, value)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return n, nil
+	case string:
+		return &yamlNode{scalar: yamlString(v)}, nil
+	case json.Number:
+		return &yamlNode{scalar: v.String()}, nil
+	case bool:
+		return &yamlNode{scalar: strconv.FormatBool(v)}, nil
+	default:
+		return &yamlNode{scalar: "null"}, nil
+	}
+}
+
+// yamlString returns the string as a plain scalar, or quoted when it would be read as another type or is ambiguous.
+func yamlString(s string) string {
+	if !yamlPlain.MatchString(s) || strings.HasSuffix(s, " ") {
+		return strconv.Quote(s)
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null":
+		return strconv.Quote(s)
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil || yamlNumber.MatchString(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// inline reports whether the node is written in the same line of its key.
+func (n *yamlNode) inline() bool {
+	return n.delim == 0 || len(n.values) == 0
+}
+
+func (n *yamlNode) write(buf *bytes.Buffer, indent int) {
+	prefix := strings.Repeat(" ", indent)
+
+	switch {
+	case n.delim == 0:
+		buf.WriteString(n.scalar)
+		buf.WriteByte('\n')
+	case len(n.values) == 0 && n.delim == '{':
+		buf.WriteString("{}\n")
+	case len(n.values) == 0:
+		buf.WriteString("[]\n")
+	case n.delim == '{':
+		for i, key := range n.keys {
+			buf.WriteString(prefix)
+			buf.WriteString(yamlString(key))
+			buf.WriteByte(':')
+			n.values[i].writeChild(buf, indent+2)
+		}
+	default:
+		for _, value := range n.values {
+			if value.inline() {
+				buf.WriteString(prefix)
+				buf.WriteString("- ")
+				value.write(buf, 0)
+				continue
+			}
+
+			// The first line of the nested collection is written after the dash.
+			start := buf.Len()
+			value.write(buf, indent+2)
+			buf.Bytes()[start+indent] = '-'
+		}
+	}
+}
+
+func (n *yamlNode) writeChild(buf *bytes.Buffer, indent int) {
+	if n.inline() {
+		buf.WriteByte(' ')
+		n.write(buf, 0)
+		return
+	}
+
+	buf.WriteByte('\n')
+	n.write(buf, indent)
+}
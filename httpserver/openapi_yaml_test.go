@@ -0,0 +1,87 @@
+package httpserver
+
+import (
+	"testing"
+)
+
+func Test_jsonToYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "scalars",
+			args: `{"b":"text","a":1.5,"t":true,"x":null,"q":"200"}`,
+			want: "b: text\na: 1.5\nt: true\nx: null\nq: \"200\"\n",
+		},
+		{
+			name: "nested",
+			args: `{"paths":{"/users/{id}":{"get":{"tags":["users"],"parameters":[{"name":"id","in":"path"}]}}}}`,
+			want: "paths:\n" +
+				"  /users/{id}:\n" +
+				"    get:\n" +
+				"      tags:\n" +
+				"        - users\n" +
+				"      parameters:\n" +
+				"        - name: id\n" +
+				"          in: path\n",
+		},
+		{
+			name: "empty collections",
+			args: `{"a":{},"b":[],"c":[{},[]]}`,
+			want: "a: {}\nb: []\nc:\n  - {}\n  - []\n",
+		},
+		{
+			name: "nested arrays",
+			args: `[[1,2],[3]]`,
+			want: "- - 1\n  - 2\n- - 3\n",
+		},
+		{name: "invalid", args: `{`, wantErr: true},
+		{name: "invalid key", args: `{1:2}`, wantErr: true},
+		{name: "invalid value", args: `{"a":}`, wantErr: true},
+		{name: "unclosed", args: `[1`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsonToYAML([]byte(tt.args))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("jsonToYAML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("jsonToYAML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_yamlString(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{name: "plain", args: "Get user", want: "Get user"},
+		{name: "path", args: "/users/{id}", want: "/users/{id}"},
+		{name: "reference", args: "$ref", want: "$ref"},
+		{name: "version", args: "1.0.0", want: "1.0.0"},
+		{name: "empty", args: "", want: `""`},
+		{name: "colon", args: "a: b", want: `"a: b"`},
+		{name: "comment", args: "a #b", want: `"a #b"`},
+		{name: "indicator", args: "-a", want: `"-a"`},
+		{name: "trailing space", args: "a ", want: `"a "`},
+		{name: "new line", args: "a\nb", want: `"a\nb"`},
+		{name: "bool", args: "Yes", want: `"Yes"`},
+		{name: "null", args: "null", want: `"null"`},
+		{name: "number", args: "1e3", want: `"1e3"`},
+		{name: "hex", args: "0x1F", want: `"0x1F"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := yamlString(tt.args); got != tt.want {
+				t.Errorf("yamlString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
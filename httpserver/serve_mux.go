@@ -5,33 +5,37 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
+	"sync"
 )
 
 type (
 	// Handle interface allows the registration of HTTP handlers under the current routing path plus the specified pattern.
 	// It includes methods for each standard HTTP method.
+	// A variadic set of [OptionRoute] used to configure the behavior of the registered handler.
 	Handle interface {
 		// Connect registers a handler for the HTTP CONNECT method, under the current routing path plus the specified pattern.
-		Connect(pattern string, handlerFn http.HandlerFunc)
+		Connect(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute)
 		// Delete registers a handler for the HTTP DELETE method, under the current routing path plus the specified pattern.
-		Delete(pattern string, handlerFn http.HandlerFunc)
+		Delete(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute)
 		// Get registers a handler for the HTTP GET method, under the current routing path plus the specified pattern.
-		Get(pattern string, handlerFn http.HandlerFunc)
+		Get(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute)
 		// Head registers a handler for the HTTP HEAD method, under the current routing path plus the specified pattern.
-		Head(pattern string, handlerFn http.HandlerFunc)
+		Head(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute)
 		// Options registers a handler for the HTTP OPTIONS method, under the current routing path plus the specified pattern.
-		Options(pattern string, handlerFn http.HandlerFunc)
+		Options(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute)
 		// Patch registers a handler for the HTTP PATCH method, under the current routing path plus the specified pattern.
-		Patch(pattern string, handlerFn http.HandlerFunc)
+		Patch(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute)
 		// Post registers a handler for the HTTP POST method, under the current routing path plus the specified pattern.
-		Post(pattern string, handlerFn http.HandlerFunc)
+		Post(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute)
 		// Put registers a handler for the HTTP PUT method, under the current routing path plus the specified pattern.
-		Put(pattern string, handlerFn http.HandlerFunc)
+		Put(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute)
 		// Trace registers a handler for the HTTP TRACE method, under the current routing path plus the specified pattern.
-		Trace(pattern string, handlerFn http.HandlerFunc)
+		Trace(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute)
 		// Method registers a handler for the custom HTTP method, under the current routing path plus the specified pattern.
-		Method(method, pattern string, handlerFn http.HandlerFunc)
+		Method(method, pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute)
 	}
 
 	// Router interface extends the [Handle] interface.
@@ -44,9 +48,14 @@ type (
 		// With appends one or more middlewares to the middleware stack in the current routing path to register the inline Handle.
 		With(middlewares ...func(http.Handler) http.Handler) Handle
 		// Group return a new inline Router under the current routing path plus the specified pattern, inheriting the middleware stack.
-		Group(pattern string) Router
+		// The variadic set of [OptionRoute] is applied to every handler registered in the Router.
+		Group(pattern string, opts ...OptionRoute) Router
 		// Route allowing additional routes to be defined within the subrouter under the current routing path plus the specified pattern, inheriting the middleware stack.
-		Route(pattern string, fn func(subMux Router))
+		// The variadic set of [OptionRoute] is applied to every handler registered in the subrouter.
+		Route(pattern string, fn func(subMux Router), opts ...OptionRoute)
+		// Mount registers the handler for every HTTP method under the current routing path plus the specified pattern and its subpaths,
+		// inheriting the middleware stack. The routing path is stripped from the request URL before calling the handler.
+		Mount(pattern string, handler http.Handler, opts ...OptionRoute)
 	}
 
 	// ServeMux extends [http.Handler] designed to manage routing paths, middleware registration,
@@ -59,6 +68,11 @@ type (
 	ServeMux interface {
 		Router
 		http.Handler
+
+		// Routes returns the handlers registered in the ServeMux, in the order of registration.
+		Routes() Routes
+		// OpenAPI returns the generator of the OpenAPI 3.1 document of the handlers registered in the ServeMux.
+		OpenAPI(opts ...OptionOpenAPI) *OpenAPI
 	}
 
 	serveMuxConfig struct {
@@ -66,6 +80,8 @@ type (
 		handlerMethodNotAllowed http.HandlerFunc
 		cors                    *cors
 		handlerOptionsMaxAge    int
+		openAPI                 *openAPIConfig
+		mountOpenAPI            func()
 	}
 
 	serveMux struct {
@@ -73,7 +89,10 @@ type (
 		middlewares  []func(http.Handler) http.Handler
 		patternRoute *patternRoute
 		routes       map[string]*serveMuxRoute
+		registered   *Routes
+		operations   *[]routeOperation
 		config       *serveMuxConfig
+		routeOptions []OptionRoute
 	}
 
 	// OptionServeMux is used to apply configurations to a [ServeMux] when creating it with [NewServeMux].
@@ -88,6 +107,8 @@ func NewServeMux(opts ...OptionServeMux) ServeMux {
 		middlewares:  []func(http.Handler) http.Handler{},
 		patternRoute: newPatternRoute(""),
 		routes:       map[string]*serveMuxRoute{},
+		registered:   &Routes{},
+		operations:   &[]routeOperation{},
 		config: &serveMuxConfig{
 			handlerNotFound:         defaultHandlerNotFound,
 			handlerMethodNotAllowed: defaultHandlerMethodNotAllowed,
@@ -99,6 +120,10 @@ func NewServeMux(opts ...OptionServeMux) ServeMux {
 		opt(mux)
 	}
 
+	if mux.config.openAPI != nil {
+		mux.config.mountOpenAPI = sync.OnceFunc(mux.mountOpenAPI)
+	}
+
 	return mux
 }
 
@@ -114,30 +139,41 @@ func (mux *serveMux) With(middlewares ...func(http.Handler) http.Handler) Handle
 		middlewares:  append(mux.middlewares, middlewares...),
 		patternRoute: mux.patternRoute,
 		routes:       mux.routes,
+		registered:   mux.registered,
+		operations:   mux.operations,
 		config:       mux.config,
+		routeOptions: mux.routeOptions,
 	}
 }
 
 // Group return a new inline [Router] under the current routing path plus the specified pattern, inheriting the middleware stack.
-func (mux *serveMux) Group(pattern string) Router {
+// The variadic set of [OptionRoute] is applied to every handler registered in the [Router].
+func (mux *serveMux) Group(pattern string, opts ...OptionRoute) Router {
 	return &serveMux{
 		serveMux:     mux.serveMux,
 		middlewares:  mux.middlewares,
 		patternRoute: mux.patternRoute.join(pattern),
 		routes:       mux.routes,
+		registered:   mux.registered,
+		operations:   mux.operations,
 		config:       mux.config,
+		routeOptions: append(slices.Clip(mux.routeOptions), opts...),
 	}
 }
 
 // Route allowing additional routes to be defined within the sub-[Router] under the current routing path plus the
 // specified pattern, inheriting the middleware stack.
-func (mux *serveMux) Route(pattern string, fn func(sub Router)) {
+// The variadic set of [OptionRoute] is applied to every handler registered in the sub-[Router].
+func (mux *serveMux) Route(pattern string, fn func(sub Router), opts ...OptionRoute) {
 	subRouter := &serveMux{
 		serveMux:     mux.serveMux,
 		middlewares:  mux.middlewares,
 		patternRoute: mux.patternRoute.join(pattern),
 		routes:       mux.routes,
+		registered:   mux.registered,
+		operations:   mux.operations,
 		config:       mux.config,
+		routeOptions: append(slices.Clip(mux.routeOptions), opts...),
 	}
 	fn(subRouter)
 }
@@ -151,19 +187,25 @@ func validateHandler(handler http.Handler) {
 	}
 }
 
-func (mux *serveMux) mountMiddlewares(smr *serveMuxRoute, handler http.Handler) http.Handler {
+func (mux *serveMux) mountMiddlewares(smr *serveMuxRoute, rc *routeConfig, handler http.Handler) (http.Handler, int) {
+	middlewares := len(mux.middlewares)
 	if smr.cors != nil {
 		handler = smr.middlewareCors(handler)
+		middlewares++
 	}
 	for i := len(mux.middlewares) - 1; i >= 0; i-- {
 		handler = mux.middlewares[i](handler)
 	}
-	return handler
+	handler, routeMiddlewares := rc.mountMiddlewares(handler)
+	return handler, middlewares + routeMiddlewares
 }
 
-func (mux *serveMux) registerHandle(pattern, handlerKind string, handlerFn http.Handler) {
-	slog.Info(fmt.Sprintf("[Register HTTP %s] %s", handlerKind, pattern))
-	mux.serveMux.Handle(pattern, handlerFn)
+func (mux *serveMux) registerHandle(pattern string, kind RouteKind, smr *serveMuxRoute, rc *routeConfig, handlerFn http.Handler) {
+	handler, middlewares := mux.mountMiddlewares(smr, rc, handlerFn)
+
+	slog.Info(fmt.Sprintf("[Register HTTP %s] %s", kind, pattern))
+	mux.serveMux.Handle(pattern, handler)
+	*mux.registered = append(*mux.registered, newRoute(pattern, kind, middlewares))
 }
 
 func (mux *serveMux) registerServeMuxRoute(pattern string, createFn func(smr *serveMuxRoute)) *serveMuxRoute {
@@ -181,14 +223,16 @@ func (mux *serveMux) registerServeMuxRoute(pattern string, createFn func(smr *se
 	return smr
 }
 
-func (mux *serveMux) addRoute(method, pattern string, handlerFn http.Handler) {
+func (mux *serveMux) addRoute(method, pattern string, handlerFn http.Handler, opts []OptionRoute) {
 	validateHandler(handlerFn)
 
 	pr := mux.patternRoute.join(pattern)
+	rcRouter := newRouteConfig(mux.routeOptions)
+	rc := newRouteConfig(mux.routeOptions, opts...)
 
 	mux.registerServeMuxRoute(pr.host+"/", func(smr *serveMuxRoute) {
 		smr.addMethod(http.MethodOptions)
-		mux.registerHandle(pr.host+"/", "HandlerNotFound", mux.mountMiddlewares(smr, http.HandlerFunc(mux.config.handlerNotFound)))
+		mux.registerHandle(pr.host+"/", RouteKindNotFound, smr, newRouteConfig(nil), http.HandlerFunc(mux.config.handlerNotFound))
 	})
 
 	patternMethodNotAllowed := pr.mountMethodNotAllowed()
@@ -200,7 +244,7 @@ func (mux *serveMux) addRoute(method, pattern string, handlerFn http.Handler) {
 			handlerMethodNotAllowed = smr.middlewareMethodNotAllowed(handlerMethodNotAllowed)
 		}
 
-		mux.registerHandle(patternMethodNotAllowed, "HandlerMethodNotAllowed", mux.mountMiddlewares(smr, handlerMethodNotAllowed))
+		mux.registerHandle(patternMethodNotAllowed, RouteKindMethodNotAllowed, smr, rcRouter, handlerMethodNotAllowed)
 	})
 	smr.addMethod(method)
 
@@ -208,64 +252,167 @@ func (mux *serveMux) addRoute(method, pattern string, handlerFn http.Handler) {
 		handlerFn = smr.middlewareMethodNotAllowed(handlerFn)
 	}
 
-	mux.registerHandle(strings.TrimSpace(fmt.Sprintf("%s %s", method, pr.String())), "HandlerFn", mux.mountMiddlewares(smr, handlerFn))
+	mux.registerHandle(strings.TrimSpace(fmt.Sprintf("%s %s", method, pr.String())), RouteKindHandlerFn, smr, rc, handlerFn)
+	*mux.operations = append(*mux.operations, routeOperation{method: method, patternRoute: pr, config: rc})
+}
+
+// Mount registers the handler for every HTTP method under the current routing path plus the specified pattern and its subpaths,
+// inheriting the middleware stack, like http.FileServer or another [ServeMux].
+// A variadic set of [OptionRoute] used to configure the behavior of the registered handler.
+//
+// Behavior:
+//   - The pattern is registered with a trailing slash, the requests to the pattern without it are redirected.
+//   - The routing path, including its wildcards, is stripped from the request URL before calling the handler.
+//   - The wildcards of the routing path remain available with [http.Request.PathValue].
+//   - The handlers registered in the subpaths of the pattern take precedence over the mounted handler.
+//   - Mounting in the root of the host replaces the handler for not found routes of the host.
+//
+// Important Note:
+//   - If the pattern is already registered, or handlers were registered before mounting in the root of the host, it will cause a panic.
+//   - The mounted handler is not part of the OpenAPI document.
+func (mux *serveMux) Mount(pattern string, handler http.Handler, opts ...OptionRoute) {
+	validateHandler(handler)
+
+	pr := mux.patternRoute.join(pattern)
+	if !pr.endSlash {
+		pr.pattern = pr.pattern + "/"
+		pr.endSlash = true
+	}
+	rc := newRouteConfig(mux.routeOptions, opts...)
+
+	patternMount := pr.String()
+	if patternMount != pr.host+"/" {
+		mux.registerServeMuxRoute(pr.host+"/", func(smr *serveMuxRoute) {
+			smr.addMethod(http.MethodOptions)
+			mux.registerHandle(pr.host+"/", RouteKindNotFound, smr, newRouteConfig(nil), http.HandlerFunc(mux.config.handlerNotFound))
+		})
+	}
+
+	if _, ok := mux.routes[patternMount]; ok {
+		panic(fmt.Errorf("httpserver: mount pattern %s conflicts with the registered handlers", patternMount))
+	}
+
+	handler = stripSegments(strings.Count(pr.pattern, "/")-1, handler)
+	mux.registerServeMuxRoute(patternMount, func(smr *serveMuxRoute) {
+		for _, method := range []string{http.MethodDelete, http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPatch, http.MethodPost, http.MethodPut} {
+			smr.addMethod(method)
+		}
+		mux.registerHandle(patternMount, RouteKindMount, smr, rc, handler)
+	})
+}
+
+// stripSegments removes the first segments of the request URL path.
+func stripSegments(segments int, next http.Handler) http.Handler {
+	if segments == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r2 := new(http.Request)
+		*r2 = *r
+		r2.URL = new(url.URL)
+		*r2.URL = *r.URL
+
+		if r.URL.RawPath != "" {
+			// The segments are counted in the escaped path, an escaped slash is part of its segment.
+			r2.URL.RawPath = trimSegments(r.URL.RawPath, segments)
+			r2.URL.Path, _ = url.PathUnescape(r2.URL.RawPath)
+		} else {
+			r2.URL.Path = trimSegments(r.URL.Path, segments)
+		}
+
+		next.ServeHTTP(w, r2)
+	})
+}
+
+func trimSegments(path string, segments int) string {
+	for range segments {
+		i := strings.IndexByte(path[1:], '/')
+		if i < 0 {
+			return "/"
+		}
+		path = path[i+1:]
+	}
+	return path
 }
 
 // Connect registers a handler for the HTTP CONNECT method, under the current routing path plus the specified pattern.
-func (mux *serveMux) Connect(pattern string, handlerFn http.HandlerFunc) {
-	mux.addRoute(http.MethodConnect, pattern, handlerFn)
+func (mux *serveMux) Connect(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute) {
+	mux.addRoute(http.MethodConnect, pattern, handlerFn, opts)
 }
 
 // Delete registers a handler for the HTTP DELETE method, under the current routing path plus the specified pattern.
-func (mux *serveMux) Delete(pattern string, handlerFn http.HandlerFunc) {
-	mux.addRoute(http.MethodDelete, pattern, handlerFn)
+func (mux *serveMux) Delete(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute) {
+	mux.addRoute(http.MethodDelete, pattern, handlerFn, opts)
 }
 
 // Get registers a handler for the HTTP GET method, under the current routing path plus the specified pattern.
-func (mux *serveMux) Get(pattern string, handlerFn http.HandlerFunc) {
-	mux.addRoute(http.MethodGet, pattern, handlerFn)
+func (mux *serveMux) Get(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute) {
+	mux.addRoute(http.MethodGet, pattern, handlerFn, opts)
 }
 
 // Head registers a handler for the HTTP HEAD method, under the current routing path plus the specified pattern.
-func (mux *serveMux) Head(pattern string, handlerFn http.HandlerFunc) {
-	mux.addRoute(http.MethodHead, pattern, handlerFn)
+func (mux *serveMux) Head(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute) {
+	mux.addRoute(http.MethodHead, pattern, handlerFn, opts)
 }
 
 // Options registers a handler for the HTTP OPTIONS method, under the current routing path plus the specified pattern.
-func (mux *serveMux) Options(pattern string, handlerFn http.HandlerFunc) {
-	mux.addRoute(http.MethodOptions, pattern, handlerFn)
+func (mux *serveMux) Options(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute) {
+	mux.addRoute(http.MethodOptions, pattern, handlerFn, opts)
 }
 
 // Patch registers a handler for the HTTP PATCH method, under the current routing path plus the specified pattern.
-func (mux *serveMux) Patch(pattern string, handlerFn http.HandlerFunc) {
-	mux.addRoute(http.MethodPatch, pattern, handlerFn)
+func (mux *serveMux) Patch(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute) {
+	mux.addRoute(http.MethodPatch, pattern, handlerFn, opts)
 }
 
 // Post registers a handler for the HTTP POST method, under the current routing path plus the specified pattern.
-func (mux *serveMux) Post(pattern string, handlerFn http.HandlerFunc) {
-	mux.addRoute(http.MethodPost, pattern, handlerFn)
+func (mux *serveMux) Post(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute) {
+	mux.addRoute(http.MethodPost, pattern, handlerFn, opts)
 }
 
 // Put registers a handler for the HTTP PUT method, under the current routing path plus the specified pattern.
-func (mux *serveMux) Put(pattern string, handlerFn http.HandlerFunc) {
-	mux.addRoute(http.MethodPut, pattern, handlerFn)
+func (mux *serveMux) Put(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute) {
+	mux.addRoute(http.MethodPut, pattern, handlerFn, opts)
 }
 
 // Trace registers a handler for the HTTP TRACE method, under the current routing path plus the specified pattern.
-func (mux *serveMux) Trace(pattern string, handlerFn http.HandlerFunc) {
-	mux.addRoute(http.MethodTrace, pattern, handlerFn)
+func (mux *serveMux) Trace(pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute) {
+	mux.addRoute(http.MethodTrace, pattern, handlerFn, opts)
 }
 
 // Method registers a handler for the custom HTTP method, under the current routing path plus the specified pattern.
-func (mux *serveMux) Method(method, pattern string, handlerFn http.HandlerFunc) {
+func (mux *serveMux) Method(method, pattern string, handlerFn http.HandlerFunc, opts ...OptionRoute) {
 	if method == "" {
 		panic(errors.New("method not specified"))
 	}
 
-	mux.addRoute(strings.ToUpper(method), pattern, handlerFn)
+	mux.addRoute(strings.ToUpper(method), pattern, handlerFn, opts)
+}
+
+// Routes returns the handlers registered in the [ServeMux], in the order of registration,
+// including the handlers registered automatically for the not found routes and the not allowed methods.
+func (mux *serveMux) Routes() Routes {
+	mux.mountLazyHandlers()
+
+	routes := make(Routes, len(*mux.registered))
+	for i, route := range *mux.registered {
+		route.Wildcards = slices.Clone(route.Wildcards)
+		routes[i] = route
+	}
+	return routes
 }
 
 // ServeHTTP dispatches the request to the handler whose pattern most closely matches the request URL.
 func (mux *serveMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	mux.mountLazyHandlers()
 	mux.serveMux.ServeHTTP(w, r)
 }
+
+// mountLazyHandlers registers the handlers deferred until the ServeMux is used, like the handlers of [WithOpenAPI],
+// so they inherit the middleware stack appended after the ServeMux was created.
+func (mux *serveMux) mountLazyHandlers() {
+	if mux.config.mountOpenAPI != nil {
+		mux.config.mountOpenAPI()
+	}
+}
@@ -0,0 +1,125 @@
+package httpserver
+
+import (
+	"strings"
+)
+
+type (
+	openAPIConfig struct {
+		pattern     string
+		uiPattern   string
+		title       string
+		description string
+		version     string
+		servers     []string
+	}
+
+	// OptionOpenAPI is used to apply configurations to the OpenAPI document when creating it with [WithOpenAPI] or [ServeMux.OpenAPI].
+	OptionOpenAPI func(*openAPIConfig)
+)
+
+func newOpenAPIConfig(pattern string, opts []OptionOpenAPI) *openAPIConfig {
+	if pattern == "" {
+		pattern = "/openapi"
+	}
+
+	c := &openAPIConfig{
+		pattern: strings.TrimSuffix(pattern, "/"),
+		title:   "API",
+		version: "1.0.0",
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// WithOpenAPI is an [OptionServeMux] that serves the OpenAPI 3.1 document of the handlers registered in the [ServeMux].
+// A variadic set of [OptionOpenAPI] used to configure the document.
+//
+// Default:
+//   - The default pattern is /openapi.
+//
+// Behavior:
+//   - Registers a handler for the HTTP GET method in pattern.json, serving the document in the JSON format.
+//   - Registers a handler for the HTTP GET method in pattern.yaml, serving the document in the YAML format.
+//   - Registers the handler of the documentation page if defined [WithOpenAPIUI].
+//   - The document is generated on each request, see [ServeMux.OpenAPI].
+//
+// Important Note:
+//   - The handlers are registered on the first request or call to [ServeMux.Routes], with the middleware stack of the [ServeMux]
+//     at that moment, so the middlewares must be appended with [Router.Use] before serving.
+//   - If the patterns are already registered, the registration will cause a panic.
+//   - The handlers are hidden from the document.
+func WithOpenAPI(pattern string, opts ...OptionOpenAPI) OptionServeMux {
+	return func(mux *serveMux) {
+		mux.config.openAPI = newOpenAPIConfig(pattern, opts)
+	}
+}
+
+// WithOpenAPITitle is an [OptionOpenAPI] that defines the title of the API.
+//
+// Default:
+//   - The default title is API.
+func WithOpenAPITitle(title string) OptionOpenAPI {
+	return func(c *openAPIConfig) {
+		if title != "" {
+			c.title = title
+		}
+	}
+}
+
+// WithOpenAPIDescription is an [OptionOpenAPI] that defines the description of the API.
+func WithOpenAPIDescription(description string) OptionOpenAPI {
+	return func(c *openAPIConfig) {
+		c.description = description
+	}
+}
+
+// WithOpenAPIVersion is an [OptionOpenAPI] that defines the version of the API.
+//
+// Default:
+//   - The default version is 1.0.0.
+func WithOpenAPIVersion(version string) OptionOpenAPI {
+	return func(c *openAPIConfig) {
+		if version != "" {
+			c.version = version
+		}
+	}
+}
+
+// WithOpenAPIServers is an [OptionOpenAPI] that appends the URLs of the servers of the API.
+func WithOpenAPIServers(urls ...string) OptionOpenAPI {
+	return func(c *openAPIConfig) {
+		for _, url := range urls {
+			if url != "" {
+				c.servers = append(c.servers, url)
+			}
+		}
+	}
+}
+
+// WithOpenAPIUI is an [OptionOpenAPI] that serves a documentation page of the document in the pattern, like /docs.
+// The page is self-hosted, it does not load any external resource.
+// The document is loaded with a URL relative to the page, so it works when the [ServeMux] is mounted under a prefix.
+// Only used with [WithOpenAPI].
+func WithOpenAPIUI(pattern string) OptionOpenAPI {
+	return func(c *openAPIConfig) {
+		c.uiPattern = pattern
+	}
+}
+
+func (mux *serveMux) mountOpenAPI() {
+	o := &OpenAPI{
+		mux:    mux,
+		config: mux.config.openAPI,
+	}
+
+	mux.Get(o.config.pattern+".json", o.serveJSON, WithOpenAPIHidden())
+	mux.Get(o.config.pattern+".yaml", o.serveYAML, WithOpenAPIHidden())
+	if o.config.uiPattern != "" {
+		mux.Get(o.config.uiPattern, o.serveUI, WithOpenAPIHidden())
+	}
+}
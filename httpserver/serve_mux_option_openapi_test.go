@@ -0,0 +1,120 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_newOpenAPIConfig(t *testing.T) {
+	type args struct {
+		pattern string
+		opts    []OptionOpenAPI
+	}
+	tests := []struct {
+		name string
+		args args
+		want *openAPIConfig
+	}{
+		{
+			name: "default",
+			want: &openAPIConfig{pattern: "/openapi", title: "API", version: "1.0.0"},
+		},
+		{
+			name: "options",
+			args: args{
+				pattern: "/spec/",
+				opts: []OptionOpenAPI{
+					WithOpenAPITitle("Users"),
+					WithOpenAPIDescription("Users API"),
+					WithOpenAPIVersion("2.0.0"),
+					WithOpenAPIServers("https://a.test.com", "", "https://b.test.com"),
+					WithOpenAPIUI("/docs"),
+				},
+			},
+			want: &openAPIConfig{
+				pattern:     "/spec",
+				uiPattern:   "/docs",
+				title:       "Users",
+				description: "Users API",
+				version:     "2.0.0",
+				servers:     []string{"https://a.test.com", "https://b.test.com"},
+			},
+		},
+		{
+			name: "empty values",
+			args: args{
+				opts: []OptionOpenAPI{WithOpenAPITitle(""), WithOpenAPIVersion("")},
+			},
+			want: &openAPIConfig{pattern: "/openapi", title: "API", version: "1.0.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newOpenAPIConfig(tt.args.pattern, tt.args.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newOpenAPIConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithOpenAPI(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []OptionOpenAPI
+		want []string
+	}{
+		{
+			name: "document",
+			want: []string{"GET /openapi.json", "GET /openapi.yaml"},
+		},
+		{
+			name: "ui",
+			opts: []OptionOpenAPI{WithOpenAPIUI("/docs")},
+			want: []string{"GET /openapi.json", "GET /openapi.yaml", "GET /docs"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := NewServeMux(WithOpenAPI("", tt.opts...))
+
+			var got []string
+			for _, route := range mux.Routes() {
+				if route.Kind == RouteKindHandlerFn {
+					got = append(got, route.Method+" "+route.Pattern)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Routes() = %v, want %v", got, tt.want)
+			}
+
+			if doc, _ := mux.OpenAPI().document(); len(doc.Paths) != 0 {
+				t.Errorf("OpenAPI paths = %v, want empty", doc.Paths)
+			}
+		})
+	}
+}
+
+func TestWithOpenAPI_middlewares(t *testing.T) {
+	mux := NewServeMux(WithOpenAPI("", WithOpenAPIUI("/docs")))
+	mux.Use(middlewareWrite("mux"))
+
+	for _, path := range []string{"/openapi.json", "/openapi.yaml", "/docs"} {
+		t.Run(path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+			if got := w.Body.String(); !strings.HasPrefix(got, "BEFORE:mux -> ") || !strings.HasSuffix(got, " -> AFTER:mux") {
+				t.Errorf("body = %v, want wrapped by the middleware stack", got)
+			}
+		})
+	}
+
+	for _, route := range mux.Routes() {
+		if route.Kind == RouteKindHandlerFn && route.Middlewares != 1 {
+			t.Errorf("route %s %s middlewares = %v, want %v", route.Method, route.Pattern, route.Middlewares, 1)
+		}
+	}
+}
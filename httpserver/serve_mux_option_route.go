@@ -0,0 +1,121 @@
+package httpserver
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"slices"
+
+	"github.com/telmoandrade/go-library/logger"
+)
+
+type (
+	routeConfig struct {
+		logLevel    *slog.Level
+		summary     string
+		description string
+		tags        []string
+		request     reflect.Type
+		responses   map[int]reflect.Type
+		hidden      bool
+	}
+
+	// OptionRoute is used to apply configurations to the handlers registered with the [Handle] methods,
+	// or to every handler registered in a [Router] created with [ServeMux.Group] and [ServeMux.Route].
+	OptionRoute func(*routeConfig)
+)
+
+func newRouteConfig(routerOpts []OptionRoute, opts ...OptionRoute) *routeConfig {
+	rc := &routeConfig{
+		responses: map[int]reflect.Type{},
+	}
+
+	for _, opt := range routerOpts {
+		opt(rc)
+	}
+	for _, opt := range opts {
+		opt(rc)
+	}
+
+	return rc
+}
+
+// WithLogLevel is an [OptionRoute] that defines the minimum log level of the requests handled by the route.
+// The level is added to the context [logger.ContextMinLevel] before the middleware stack,
+// allowing noisy routes such as health checks to be silenced, including the log of [MiddlewareLogging].
+//
+// Behavior:
+//   - The X-Logger-Level header handled by [MiddlewareLogging] takes precedence over the route level.
+//   - Must be used with [logger.NewHandler] to apply the minimum log level.
+func WithLogLevel(level slog.Level) OptionRoute {
+	return func(rc *routeConfig) {
+		rc.logLevel = &level
+	}
+}
+
+func (rc *routeConfig) mountMiddlewares(handler http.Handler) (http.Handler, int) {
+	middlewares := 0
+	if rc.logLevel != nil {
+		level := *rc.logLevel
+		next := handler
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), logger.ContextMinLevel, level)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+		middlewares++
+	}
+	return handler, middlewares
+}
+
+// WithSummary is an [OptionRoute] that defines the summary of the operation in the OpenAPI document.
+func WithSummary(summary string) OptionRoute {
+	return func(rc *routeConfig) {
+		rc.summary = summary
+	}
+}
+
+// WithDescription is an [OptionRoute] that defines the description of the operation in the OpenAPI document.
+func WithDescription(description string) OptionRoute {
+	return func(rc *routeConfig) {
+		rc.description = description
+	}
+}
+
+// WithTags is an [OptionRoute] that appends one or more tags to the operation in the OpenAPI document.
+// Used in a [ServeMux.Group] or [ServeMux.Route], the tags are appended to every handler registered in the [Router].
+func WithTags(tags ...string) OptionRoute {
+	return func(rc *routeConfig) {
+		for _, tag := range tags {
+			if tag != "" && !slices.Contains(rc.tags, tag) {
+				rc.tags = append(rc.tags, tag)
+			}
+		}
+	}
+}
+
+// WithRequest is an [OptionRoute] that defines the type of the JSON request body of the operation in the OpenAPI document,
+// like WithRequest(User{}).
+// The schema of the type is generated by reflection, see [ServeMux.OpenAPI].
+func WithRequest(body any) OptionRoute {
+	return func(rc *routeConfig) {
+		rc.request = reflect.TypeOf(body)
+	}
+}
+
+// WithResponse is an [OptionRoute] that defines a response status code of the operation in the OpenAPI document,
+// and the type of its JSON body, like WithResponse(http.StatusOK, []User{}).
+// A nil body defines a response without content.
+// The schema of the type is generated by reflection, see [ServeMux.OpenAPI].
+func WithResponse(status int, body any) OptionRoute {
+	return func(rc *routeConfig) {
+		rc.responses[status] = reflect.TypeOf(body)
+	}
+}
+
+// WithOpenAPIHidden is an [OptionRoute] that hides the operation from the OpenAPI document.
+func WithOpenAPIHidden() OptionRoute {
+	return func(rc *routeConfig) {
+		rc.hidden = true
+	}
+}
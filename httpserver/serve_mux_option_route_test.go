@@ -0,0 +1,165 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/telmoandrade/go-library/logger"
+)
+
+func Test_newRouteConfig(t *testing.T) {
+	type args struct {
+		routerOpts []OptionRoute
+		opts       []OptionRoute
+	}
+	tests := []struct {
+		name string
+		args args
+		want *slog.Level
+	}{
+		{
+			name: "without option",
+		},
+		{
+			name: "router option",
+			args: args{
+				routerOpts: []OptionRoute{WithLogLevel(slog.LevelWarn)},
+			},
+			want: func() *slog.Level { l := slog.LevelWarn; return &l }(),
+		},
+		{
+			name: "route option overrides router option",
+			args: args{
+				routerOpts: []OptionRoute{WithLogLevel(slog.LevelWarn)},
+				opts:       []OptionRoute{WithLogLevel(slog.LevelDebug)},
+			},
+			want: func() *slog.Level { l := slog.LevelDebug; return &l }(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := newRouteConfig(tt.args.routerOpts, tt.args.opts...)
+			if (rc.logLevel == nil) != (tt.want == nil) || (rc.logLevel != nil && *rc.logLevel != *tt.want) {
+				t.Errorf("newRouteConfig().logLevel = %v, want %v", rc.logLevel, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithLogLevel(t *testing.T) {
+	type args struct {
+		header string
+	}
+	type want struct {
+		level slog.Level
+		ok    bool
+	}
+	tests := []struct {
+		name    string
+		pattern string
+		args    args
+		want    want
+	}{
+		{
+			name:    "route without level",
+			pattern: "/users",
+		},
+		{
+			name:    "group level",
+			pattern: "/health",
+			want:    want{level: slog.LevelWarn, ok: true},
+		},
+		{
+			name:    "route level",
+			pattern: "/health/ready",
+			want:    want{level: slog.LevelError, ok: true},
+		},
+		{
+			name:    "header precedence",
+			pattern: "/health",
+			args:    args{header: "debug"},
+			want:    want{level: slog.LevelDebug, ok: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got slog.Level
+			var ok bool
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				got, ok = r.Context().Value(logger.ContextMinLevel).(slog.Level)
+			}
+
+			mux := NewServeMux()
+			mux.Use(NewMiddlewareLogging(WithLevelVerifier(func(r *http.Request) bool { return true })))
+			mux.Get("/users", handler)
+
+			health := mux.Group("/health", WithLogLevel(slog.LevelWarn))
+			health.Get("", handler)
+			health.Get("/ready", handler, WithLogLevel(slog.LevelError))
+
+			r := httptest.NewRequest(http.MethodGet, tt.pattern, nil)
+			if tt.args.header != "" {
+				r.Header.Set("X-Logger-Level", tt.args.header)
+			}
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, r)
+
+			if ok != tt.want.ok || got != tt.want.level {
+				t.Errorf("ContextMinLevel = %v, %v, want %v, %v", got, ok, tt.want.level, tt.want.ok)
+			}
+		})
+	}
+}
+
+func Test_newRouteConfig_openAPI(t *testing.T) {
+	type args struct {
+		routerOpts []OptionRoute
+		opts       []OptionRoute
+	}
+	tests := []struct {
+		name string
+		args args
+		want *routeConfig
+	}{
+		{
+			name: "without option",
+			want: &routeConfig{responses: map[int]reflect.Type{}},
+		},
+		{
+			name: "options",
+			args: args{
+				routerOpts: []OptionRoute{WithTags("users", ""), WithOpenAPIHidden()},
+				opts: []OptionRoute{
+					WithSummary("Get user"),
+					WithDescription("Returns the user"),
+					WithTags("read", "users"),
+					WithRequest(schemaAddress{}),
+					WithResponse(http.StatusOK, &schemaAddress{}),
+					WithResponse(http.StatusNotFound, nil),
+				},
+			},
+			want: &routeConfig{
+				summary:     "Get user",
+				description: "Returns the user",
+				tags:        []string{"users", "read"},
+				request:     reflect.TypeFor[schemaAddress](),
+				responses: map[int]reflect.Type{
+					http.StatusOK:       reflect.TypeFor[*schemaAddress](),
+					http.StatusNotFound: nil,
+				},
+				hidden: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newRouteConfig(tt.args.routerOpts, tt.args.opts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newRouteConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package httpserver
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+type (
+	// RouteKind identifies the kind of the handler registered for a [Route].
+	RouteKind string
+
+	// Route describes a handler registered in the [ServeMux], including the handlers registered automatically.
+	Route struct {
+		// Method is the HTTP method of the handler, empty when the handler serves every method.
+		Method string
+		// Pattern is the full pattern of the handler, the host plus the path.
+		Pattern string
+		// Wildcards are the names of the wildcards of the path, in the order they appear in the pattern.
+		Wildcards []string
+		// Middlewares is the number of middlewares mounted in the handler: the stack added with [Router.Use],
+		// the CORS middleware and the route middlewares such as [WithLogLevel].
+		Middlewares int
+		// Kind is the kind of the handler.
+		Kind RouteKind
+	}
+
+	// Routes is the list of the handlers registered in the [ServeMux], in the order of registration.
+	Routes []Route
+
+	// WalkFunc is the function called by [Walk] for each registered [Route].
+	WalkFunc func(route Route) error
+)
+
+const (
+	// RouteKindHandlerFn is the kind of the handlers registered with the [Handle] methods.
+	RouteKindHandlerFn RouteKind = "HandlerFn"
+	// RouteKindNotFound is the kind of the handlers registered automatically for the not found routes of a host.
+	RouteKindNotFound RouteKind = "HandlerNotFound"
+	// RouteKindMethodNotAllowed is the kind of the handlers registered automatically for the not allowed methods of a path.
+	RouteKindMethodNotAllowed RouteKind = "HandlerMethodNotAllowed"
+	// RouteKindMount is the kind of the handlers registered with [ServeMux.Mount].
+	RouteKindMount RouteKind = "HandlerMount"
+)
+
+func newRoute(pattern string, kind RouteKind, middlewares int) Route {
+	method, path, ok := strings.Cut(pattern, " ")
+	if !ok {
+		method, path = "", pattern
+	}
+
+	return Route{
+		Method:      method,
+		Pattern:     path,
+		Wildcards:   newPatternRoute(path).wildcardNames(),
+		Middlewares: middlewares,
+		Kind:        kind,
+	}
+}
+
+// Walk calls fn for each [Route] registered in the [ServeMux], in the order of registration.
+// If fn returns an error, the walk stops and the error is returned.
+func Walk(mux ServeMux, fn WalkFunc) error {
+	for _, route := range mux.Routes() {
+		if err := fn(route); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String returns the routes formatted as an aligned table, with a header line.
+//
+// Behavior:
+//   - The method of the handlers serving every method is shown as *.
+//   - The wildcards are separated by commas, or shown as - when the pattern has none.
+func (rs Routes) String() string {
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "METHOD\tPATTERN\tWILDCARDS\tMIDDLEWARES\tKIND")
+	for _, route := range rs {
+		method := route.Method
+		if method == "" {
+			method = "*"
+		}
+		wildcards := strings.Join(route.Wildcards, ",")
+		if wildcards == "" {
+			wildcards = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", method, route.Pattern, wildcards, route.Middlewares, route.Kind)
+	}
+	tw.Flush()
+
+	return sb.String()
+}
@@ -0,0 +1,166 @@
+package httpserver
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func Test_serveMux_Routes(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []OptionServeMux
+		register func(mux ServeMux)
+		want     Routes
+	}{
+		{
+			name:     "empty",
+			register: func(mux ServeMux) {},
+			want:     Routes{},
+		},
+		{
+			name: "handlers",
+			register: func(mux ServeMux) {
+				mux.Use(middlewareEmpty)
+				mux.Get("/users", handlerId())
+				mux.With(middlewareEmpty).Put("/users/{id}", handlerId())
+				mux.Route("www.test.com/files", func(sub Router) {
+					sub.Get("/{dir}/{path...}", handlerId())
+				})
+			},
+			want: Routes{
+				{Pattern: "/", Middlewares: 1, Kind: RouteKindNotFound},
+				{Pattern: "/users", Middlewares: 1, Kind: RouteKindMethodNotAllowed},
+				{Method: http.MethodGet, Pattern: "/users", Middlewares: 1, Kind: RouteKindHandlerFn},
+				{Pattern: "/users/{id}", Wildcards: []string{"id"}, Middlewares: 2, Kind: RouteKindMethodNotAllowed},
+				{Method: http.MethodPut, Pattern: "/users/{id}", Wildcards: []string{"id"}, Middlewares: 2, Kind: RouteKindHandlerFn},
+				{Pattern: "www.test.com/", Middlewares: 1, Kind: RouteKindNotFound},
+				{Pattern: "www.test.com/files/{dir}/", Wildcards: []string{"dir"}, Middlewares: 1, Kind: RouteKindMethodNotAllowed},
+				{Method: http.MethodGet, Pattern: "www.test.com/files/{dir}/{path...}", Wildcards: []string{"dir", "path"}, Middlewares: 1, Kind: RouteKindHandlerFn},
+			},
+		},
+		{
+			name: "exact match",
+			register: func(mux ServeMux) {
+				mux.Group("/api").Method("purge", "/{$}", handlerId())
+			},
+			want: Routes{
+				{Pattern: "/", Kind: RouteKindNotFound},
+				{Pattern: "/api/{$}", Kind: RouteKindMethodNotAllowed},
+				{Method: "PURGE", Pattern: "/api/{$}", Kind: RouteKindHandlerFn},
+			},
+		},
+		{
+			name: "cors and route middlewares",
+			opts: []OptionServeMux{WithCors()},
+			register: func(mux ServeMux) {
+				mux.Use(middlewareEmpty)
+				mux.Get("/health", handlerId(), WithLogLevel(slog.LevelWarn))
+			},
+			want: Routes{
+				{Pattern: "/", Middlewares: 2, Kind: RouteKindNotFound},
+				{Pattern: "/health", Middlewares: 2, Kind: RouteKindMethodNotAllowed},
+				{Method: http.MethodGet, Pattern: "/health", Middlewares: 3, Kind: RouteKindHandlerFn},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := NewServeMux(tt.opts...)
+			tt.register(mux)
+
+			if got := mux.Routes(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serveMux.Routes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_serveMux_Routes_copy(t *testing.T) {
+	mux := NewServeMux()
+	mux.Get("/users/{id}", handlerId())
+
+	routes := mux.Routes()
+	routes[0].Pattern = "/changed"
+	routes[1].Wildcards[0] = "changed"
+
+	if got := mux.Routes(); got[0].Pattern != "/" || got[1].Wildcards[0] != "id" {
+		t.Errorf("serveMux.Routes() = %v, want not changed", got)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	mux := NewServeMux()
+	mux.Get("/users", handlerId())
+	mux.Post("/users", handlerId())
+
+	errStop := errors.New("stop")
+	tests := []struct {
+		name    string
+		stopAt  int
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "every route",
+			want: []string{" /", " /users", "GET /users", "POST /users"},
+		},
+		{
+			name:    "stopped",
+			stopAt:  2,
+			want:    []string{" /", " /users"},
+			wantErr: errStop,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := Walk(mux, func(route Route) error {
+				if len(got) == tt.stopAt && tt.stopAt > 0 {
+					return errStop
+				}
+				got = append(got, route.Method+" "+route.Pattern)
+				return nil
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Walk() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Walk() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoutes_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		routes Routes
+		want   string
+	}{
+		{
+			name: "empty",
+			want: "METHOD  PATTERN  WILDCARDS  MIDDLEWARES  KIND\n",
+		},
+		{
+			name: "routes",
+			routes: Routes{
+				{Pattern: "/", Middlewares: 1, Kind: RouteKindNotFound},
+				{Method: http.MethodGet, Pattern: "/files/{dir}/{path...}", Wildcards: []string{"dir", "path"}, Middlewares: 2, Kind: RouteKindHandlerFn},
+			},
+			want: "METHOD  PATTERN                 WILDCARDS  MIDDLEWARES  KIND\n" +
+				"*       /                       -          1            HandlerNotFound\n" +
+				"GET     /files/{dir}/{path...}  dir,path   2            HandlerFn\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.routes.String(); got != tt.want {
+				t.Errorf("Routes.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"slices"
 	"testing"
+	"testing/fstest"
 )
 
 func handlerId() http.HandlerFunc {
@@ -450,7 +451,7 @@ func Test_serveMux_mountMiddlewares(t *testing.T) {
 			smr := &serveMuxRoute{
 				cors: tt.args.cors,
 			}
-			handler := mux.mountMiddlewares(smr, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler, _ := mux.mountMiddlewares(smr, newRouteConfig(nil), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				w.Write([]byte("DATA"))
 			}))
 
@@ -518,7 +519,7 @@ func Test_serveMux_registerHandle(t *testing.T) {
 			ts := httptest.NewServer(mux)
 			defer ts.Close()
 
-			mux.registerHandle(tt.args.pattern, "handlerKind", handlerId())
+			mux.registerHandle(tt.args.pattern, "handlerKind", &serveMuxRoute{}, newRouteConfig(nil), handlerId())
 
 			_, _, got := testRequest(t, ts, http.MethodGet, tt.args.path, nil)
 			if got != tt.want {
@@ -757,7 +758,7 @@ func Test_serveMux_addRoute(t *testing.T) {
 			mux := muxInterface.(*serveMux)
 			mux.config.cors = tt.args.cors
 
-			mux.addRoute(tt.args.method, tt.args.pattern, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			mux.addRoute(tt.args.method, tt.args.pattern, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), nil)
 
 			for k, smr := range mux.routes {
 				if want, ok := tt.want.routes[k]; !ok {
@@ -772,6 +773,260 @@ func Test_serveMux_addRoute(t *testing.T) {
 	}
 }
 
+func Test_serveMux_Mount(t *testing.T) {
+	handlerPath := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(fmt.Sprintf("%s %s %s tenant:%s", r.Method, r.URL.Path, r.URL.RawPath, r.PathValue("tenant"))))
+	})
+
+	sub := NewServeMux()
+	sub.Get("/users/{id}", handlerId())
+
+	type args struct {
+		method string
+		path   string
+		host   string
+	}
+	type want struct {
+		status   int
+		location string
+		body     string
+	}
+	tests := []struct {
+		name  string
+		mount func(mux ServeMux)
+		args  args
+		want  want
+	}{
+		{
+			name:  "strip prefix",
+			mount: func(mux ServeMux) { mux.Mount("/legacy", handlerPath) },
+			args:  args{method: http.MethodPost, path: "/legacy/orders/1"},
+			want:  want{status: http.StatusOK, body: "POST /orders/1  tenant:"},
+		},
+		{
+			name:  "head",
+			mount: func(mux ServeMux) { mux.Mount("/legacy", handlerPath) },
+			args:  args{method: http.MethodHead, path: "/legacy/orders/1"},
+			want:  want{status: http.StatusOK, body: "HEAD /orders/1  tenant:"},
+		},
+		{
+			name:  "mount root",
+			mount: func(mux ServeMux) { mux.Mount("/legacy/", handlerPath) },
+			args:  args{method: http.MethodGet, path: "/legacy/"},
+			want:  want{status: http.StatusOK, body: "GET /  tenant:"},
+		},
+		{
+			name:  "redirect without trailing slash",
+			mount: func(mux ServeMux) { mux.Mount("/legacy", handlerPath) },
+			args:  args{method: http.MethodGet, path: "/legacy"},
+			want:  want{location: "/legacy/"},
+		},
+		{
+			name:  "escaped path",
+			mount: func(mux ServeMux) { mux.Mount("/legacy", handlerPath) },
+			args:  args{method: http.MethodGet, path: "/legacy/a%2Fb/c"},
+			want:  want{status: http.StatusOK, body: "GET /a/b/c /a%2Fb/c tenant:"},
+		},
+		{
+			name: "wildcard prefix",
+			mount: func(mux ServeMux) {
+				mux.Route("/tenants/{tenant}", func(sub Router) {
+					sub.Mount("/legacy", handlerPath)
+				})
+			},
+			args: args{method: http.MethodDelete, path: "/tenants/acme/legacy/orders"},
+			want: want{status: http.StatusOK, body: "DELETE /orders  tenant:acme"},
+		},
+		{
+			name:  "host pattern",
+			mount: func(mux ServeMux) { mux.Group("www.test.com").Mount("/legacy", handlerPath) },
+			args:  args{method: http.MethodGet, path: "/legacy/orders", host: "www.test.com"},
+			want:  want{status: http.StatusOK, body: "GET /orders  tenant:"},
+		},
+		{
+			name:  "host pattern not matched",
+			mount: func(mux ServeMux) { mux.Group("www.test.com").Mount("/legacy", handlerPath) },
+			args:  args{method: http.MethodGet, path: "/legacy/orders", host: "api.test.com"},
+			want:  want{status: http.StatusNotFound, body: "404 page not found\n"},
+		},
+		{
+			name:  "host root",
+			mount: func(mux ServeMux) { mux.Mount("www.test.com/", handlerPath) },
+			args:  args{method: http.MethodGet, path: "/orders", host: "www.test.com"},
+			want:  want{status: http.StatusOK, body: "GET /orders  tenant:"},
+		},
+		{
+			name: "middleware stack",
+			mount: func(mux ServeMux) {
+				mux.Use(middlewareWrite("mux"))
+				mux.Mount("/legacy", handlerPath)
+			},
+			args: args{method: http.MethodGet, path: "/legacy/orders"},
+			want: want{status: http.StatusOK, body: "BEFORE:mux -> GET /orders  tenant: -> AFTER:mux"},
+		},
+		{
+			name: "handler in subpath precedence",
+			mount: func(mux ServeMux) {
+				mux.Mount("/legacy", handlerPath)
+				mux.Get("/legacy/users/{id}", handlerId())
+			},
+			args: args{method: http.MethodGet, path: "/legacy/users/1"},
+			want: want{status: http.StatusOK, body: "GET /legacy/users/{id} ID:1"},
+		},
+		{
+			name: "method not allowed in subpath",
+			mount: func(mux ServeMux) {
+				mux.Mount("/legacy", handlerPath)
+				mux.Get("/legacy/users/{id}", handlerId())
+			},
+			args: args{method: http.MethodPost, path: "/legacy/users/1"},
+			want: want{status: http.StatusMethodNotAllowed, body: "405 method not allowed"},
+		},
+		{
+			name: "handler in mount pattern",
+			mount: func(mux ServeMux) {
+				mux.Mount("/legacy", handlerPath)
+				mux.Get("/legacy/", handlerId())
+			},
+			args: args{method: http.MethodPost, path: "/legacy/"},
+			want: want{status: http.StatusOK, body: "POST /  tenant:"},
+		},
+		{
+			name:  "not found outside",
+			mount: func(mux ServeMux) { mux.Mount("/legacy", handlerPath) },
+			args:  args{method: http.MethodGet, path: "/orders"},
+			want:  want{status: http.StatusNotFound, body: "404 not found"},
+		},
+		{
+			name:  "serve mux",
+			mount: func(mux ServeMux) { mux.Mount("/v1", sub) },
+			args:  args{method: http.MethodGet, path: "/v1/users/1"},
+			want:  want{status: http.StatusOK, body: "GET /users/{id} ID:1"},
+		},
+		{
+			name: "file server",
+			mount: func(mux ServeMux) {
+				mux.Mount("/static", http.FileServerFS(fstest.MapFS{"app.js": {Data: []byte("app")}}))
+			},
+			args: args{method: http.MethodGet, path: "/static/app.js"},
+			want: want{status: http.StatusOK, body: "app"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := NewServeMux()
+			tt.mount(mux)
+
+			r := httptest.NewRequest(tt.args.method, tt.args.path, nil)
+			if tt.args.host != "" {
+				r.Host = tt.args.host
+			}
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, r)
+
+			// The redirect status depends on the Go version.
+			if tt.want.location != "" && (w.Code < http.StatusMultipleChoices || w.Code >= http.StatusBadRequest) {
+				t.Errorf("status = %v, want redirect", w.Code)
+			} else if tt.want.location == "" && w.Code != tt.want.status {
+				t.Errorf("status = %v, want %v", w.Code, tt.want.status)
+			}
+			if got := w.Header().Get("Location"); got != tt.want.location {
+				t.Errorf("Location = %v, want %v", got, tt.want.location)
+			}
+			if got := w.Body.String(); tt.want.body != "" && got != tt.want.body {
+				t.Errorf("body = %v, want %v", got, tt.want.body)
+			}
+		})
+	}
+}
+
+func Test_serveMux_Mount_routes(t *testing.T) {
+	mux := NewServeMux()
+	mux.Use(middlewareEmpty)
+	mux.Mount("/tenants/{tenant}/legacy", http.NotFoundHandler())
+
+	want := Routes{
+		{Pattern: "/", Middlewares: 1, Kind: RouteKindNotFound},
+		{Pattern: "/tenants/{tenant}/legacy/", Wildcards: []string{"tenant"}, Middlewares: 1, Kind: RouteKindMount},
+	}
+	if got := mux.Routes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("serveMux.Routes() = %v, want %v", got, want)
+	}
+
+	wantMethods := []string{http.MethodDelete, http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPatch, http.MethodPost, http.MethodPut}
+	if got := mux.(*serveMux).routes["/tenants/{tenant}/legacy/"].allowedMethods; !reflect.DeepEqual(got, wantMethods) {
+		t.Errorf("smr.allowedMethods = %v, want %v", got, wantMethods)
+	}
+}
+
+func Test_serveMux_Mount_panic(t *testing.T) {
+	tests := []struct {
+		name  string
+		mount func(mux ServeMux)
+		want  string
+	}{
+		{
+			name:  "nil handler",
+			mount: func(mux ServeMux) { mux.Mount("/legacy", nil) },
+			want:  "httpserver: nil handler",
+		},
+		{
+			name: "registered pattern",
+			mount: func(mux ServeMux) {
+				mux.Get("/legacy/", handlerId())
+				mux.Mount("/legacy", handlerId())
+			},
+			want: "httpserver: mount pattern /legacy/ conflicts with the registered handlers",
+		},
+		{
+			name: "root after handlers",
+			mount: func(mux ServeMux) {
+				mux.Get("/users", handlerId())
+				mux.Mount("/", handlerId())
+			},
+			want: "httpserver: mount pattern / conflicts with the registered handlers",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if err, _ := r.(error); err == nil || err.Error() != tt.want {
+					t.Errorf("serveMux.Mount() panic = %v, want %v", r, tt.want)
+				}
+			}()
+
+			tt.mount(NewServeMux())
+		})
+	}
+}
+
+func Test_trimSegments(t *testing.T) {
+	type args struct {
+		path     string
+		segments int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{name: "without segments", args: args{path: "/a/b", segments: 0}, want: "/a/b"},
+		{name: "one segment", args: args{path: "/a/b/c", segments: 1}, want: "/b/c"},
+		{name: "two segments", args: args{path: "/a/b/c", segments: 2}, want: "/c"},
+		{name: "trailing slash", args: args{path: "/a/", segments: 1}, want: "/"},
+		{name: "every segment", args: args{path: "/a/b", segments: 2}, want: "/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := trimSegments(tt.args.path, tt.args.segments); got != tt.want {
+				t.Errorf("trimSegments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_serveMux_Connect(t *testing.T) {
 	type args struct {
 		pattern string
@@ -56,6 +56,22 @@ func (pr *patternRoute) extractWildcardSegment(seg string) {
 	}
 }
 
+// wildcardNames returns the names of the wildcards in the order they appear in the pattern.
+func (pr *patternRoute) wildcardNames() []string {
+	var names []string
+	for _, seg := range strings.Split(pr.pattern[1:], "/") {
+		if !strings.HasPrefix(seg, "{") || !strings.HasSuffix(seg, "}") {
+			continue
+		}
+
+		name := strings.TrimSuffix(seg[1:len(seg)-1], "...")
+		if name != "" && name != "$" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 func (pr *patternRoute) extractWildcard() {
 	rest := pr.pattern
 	seg := ""
@@ -1093,3 +1093,25 @@ func Test_patternRoute_mountMethodNotAllowed(t *testing.T) {
 		})
 	}
 }
+
+func Test_patternRoute_wildcardNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		want    []string
+	}{
+		{name: "root", pattern: "/"},
+		{name: "without wildcard", pattern: "host/user"},
+		{name: "wildcards", pattern: "/user/{id}/address/{address}", want: []string{"id", "address"}},
+		{name: "multiple segments", pattern: "/files/{dir}/{path...}", want: []string{"dir", "path"}},
+		{name: "exact match", pattern: "/user/{$}"},
+		{name: "anonymous", pattern: "/user/{...}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newPatternRoute(tt.pattern).wildcardNames(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("patternRoute.wildcardNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
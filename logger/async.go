@@ -0,0 +1,287 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+type (
+	// OverflowPolicy defines what the [AsyncHandler] does when the queue is full.
+	OverflowPolicy int
+
+	// AsyncHandler is a [slog.Handler] that forwards the records to the downstream handler in a background goroutine,
+	// so a slow sink does not block the goroutine that logs.
+	// It is created with [NewAsyncHandler].
+	AsyncHandler struct {
+		handler slog.Handler
+		queue   *asyncQueue
+	}
+
+	asyncQueue struct {
+		mu        sync.Mutex
+		notEmpty  *sync.Cond
+		notFull   *sync.Cond
+		entries   []asyncEntry
+		size      int
+		batch     int
+		policy    OverflowPolicy
+		queued    uint64
+		forwarded uint64
+		progress  chan struct{}
+		closed    bool
+		drained   bool
+		done      chan struct{}
+		dropped   atomic.Uint64
+	}
+
+	asyncEntry struct {
+		ctx     context.Context
+		handler slog.Handler
+		record  slog.Record
+	}
+
+	// OptionAsync is used to apply configurations to an [AsyncHandler] when creating it with [NewAsyncHandler].
+	OptionAsync func(*asyncQueue)
+)
+
+const (
+	// OverflowBlock waits until there is room in the queue.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest discards the record being logged.
+	OverflowDropNewest
+	// OverflowDropOldest discards the oldest record in the queue to make room for the record being logged.
+	OverflowDropOldest
+)
+
+var _ slog.Handler = &AsyncHandler{}
+
+// NewAsyncHandler returns a new [AsyncHandler] that forwards the records to the handler in batches.
+// A variadic set of [OptionAsync] used to configure the queue.
+//
+// Default:
+//   - The queue holds 1024 records, with the [OverflowBlock] policy.
+//   - Up to 64 records are taken from the queue in each batch.
+//
+// Important Note:
+//   - [AsyncHandler.Close] must be called before the application exits, otherwise the queued records are lost,
+//     the graceful package closes it on shutdown with NewGracefulCloser.
+//   - Errors returned by the downstream handler are discarded.
+//   - The context is forwarded without its cancellation, so the record is delivered even after the request is finished.
+//
+// Example:
+//
+//	ah := logger.NewAsyncHandler(slog.NewJSONHandler(os.Stdout, nil))
+//	slog.SetDefault(logger.NewLogger(logger.WithHandler(ah)))
+//	defer ah.Close(context.Background())
+func NewAsyncHandler(handler slog.Handler, opts ...OptionAsync) *AsyncHandler {
+	if handler == nil {
+		handler = slog.NewTextHandler(os.Stdout, nil)
+	}
+
+	q := &asyncQueue{
+		size:     1024,
+		batch:    64,
+		progress: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	q.notEmpty = sync.NewCond(&q.mu)
+	q.notFull = sync.NewCond(&q.mu)
+
+	for _, opt := range opts {
+		opt(q)
+	}
+
+	q.entries = make([]asyncEntry, 0, q.size)
+	go q.run()
+
+	return &AsyncHandler{
+		handler: handler,
+		queue:   q,
+	}
+}
+
+// WithAsyncQueueSize is an [OptionAsync] that defines the maximum number of records waiting in the queue.
+func WithAsyncQueueSize(size int) OptionAsync {
+	return func(q *asyncQueue) {
+		if size > 0 {
+			q.size = size
+		}
+	}
+}
+
+// WithAsyncOverflow is an [OptionAsync] that defines the [OverflowPolicy] applied when the queue is full.
+func WithAsyncOverflow(policy OverflowPolicy) OptionAsync {
+	return func(q *asyncQueue) {
+		q.policy = policy
+	}
+}
+
+// WithAsyncBatchSize is an [OptionAsync] that defines the maximum number of records taken from the queue in each batch.
+func WithAsyncBatchSize(size int) OptionAsync {
+	return func(q *asyncQueue) {
+		if size > 0 {
+			q.batch = size
+		}
+	}
+}
+
+func (ah *AsyncHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return ah.handler.Enabled(ctx, l)
+}
+
+// Handle queues the record to be forwarded to the downstream handler.
+// After [AsyncHandler.Close] finishes forwarding the queued records, the record is forwarded synchronously.
+func (ah *AsyncHandler) Handle(ctx context.Context, r slog.Record) error {
+	q := ah.queue
+	entry := asyncEntry{
+		ctx:     context.WithoutCancel(ctx),
+		handler: ah.handler,
+		record:  r.Clone(),
+	}
+
+	q.mu.Lock()
+	for !q.drained && len(q.entries) >= q.size {
+		switch q.policy {
+		case OverflowDropNewest:
+			q.mu.Unlock()
+			q.dropped.Add(1)
+			return nil
+		case OverflowDropOldest:
+			q.entries[0] = asyncEntry{}
+			q.entries = q.entries[1:]
+			q.dropped.Add(1)
+			q.advance(1)
+		default:
+			q.notFull.Wait()
+		}
+	}
+
+	if q.drained {
+		q.mu.Unlock()
+		return ah.handler.Handle(ctx, r)
+	}
+
+	q.entries = append(q.entries, entry)
+	q.queued++
+	q.notEmpty.Signal()
+	q.mu.Unlock()
+	return nil
+}
+
+func (ah *AsyncHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return ah
+	}
+	return &AsyncHandler{
+		handler: ah.handler.WithAttrs(attrs),
+		queue:   ah.queue,
+	}
+}
+
+func (ah *AsyncHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return ah
+	}
+	return &AsyncHandler{
+		handler: ah.handler.WithGroup(name),
+		queue:   ah.queue,
+	}
+}
+
+// Dropped returns the number of records discarded by the [OverflowPolicy].
+func (ah *AsyncHandler) Dropped() uint64 {
+	return ah.queue.dropped.Load()
+}
+
+// Flush waits until the records queued before the call are forwarded to the downstream handler,
+// the records queued after the call are not waited for.
+// It returns the context error if the context is done first.
+func (ah *AsyncHandler) Flush(ctx context.Context) error {
+	q := ah.queue
+
+	q.mu.Lock()
+	target := q.queued
+	for q.forwarded < target {
+		progress := q.progress
+		q.mu.Unlock()
+
+		select {
+		case <-progress:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		q.mu.Lock()
+	}
+	q.mu.Unlock()
+	return nil
+}
+
+// Close stops the background goroutine after forwarding the queued records to the downstream handler.
+// It returns the context error if the context is done first, the remaining records are still forwarded in the background.
+//
+// Behavior:
+//   - Records logged after the call are queued until the queue is empty, keeping their order,
+//     then they are forwarded synchronously.
+//   - Calling Close more than once is safe.
+func (ah *AsyncHandler) Close(ctx context.Context) error {
+	q := ah.queue
+
+	q.mu.Lock()
+	q.closed = true
+	q.notEmpty.Broadcast()
+	q.mu.Unlock()
+
+	select {
+	case <-q.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *asyncQueue) run() {
+	defer close(q.done)
+
+	batch := make([]asyncEntry, 0, q.batch)
+	for {
+		q.mu.Lock()
+		for len(q.entries) == 0 && !q.closed {
+			q.notEmpty.Wait()
+		}
+		if len(q.entries) == 0 {
+			// The records logged from now on are forwarded synchronously, after the queued records.
+			q.drained = true
+			q.notFull.Broadcast()
+			q.mu.Unlock()
+			return
+		}
+
+		n := min(len(q.entries), q.batch)
+		batch = append(batch[:0], q.entries[:n]...)
+		clear(q.entries[:n])
+		q.entries = q.entries[n:]
+		q.notFull.Broadcast()
+		q.mu.Unlock()
+
+		for _, e := range batch {
+			_ = e.handler.Handle(e.ctx, e.record)
+		}
+		clear(batch)
+
+		q.mu.Lock()
+		q.advance(uint64(n))
+		q.mu.Unlock()
+	}
+}
+
+// advance counts the records forwarded or dropped from the queue, waking up the callers of [AsyncHandler.Flush].
+// It must be called with the lock held.
+func (q *asyncQueue) advance(n uint64) {
+	q.forwarded += n
+	close(q.progress)
+	q.progress = make(chan struct{})
+}
@@ -0,0 +1,229 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type blockingHandler struct {
+	slog.Handler
+	release chan struct{}
+	mu      sync.Mutex
+	msgs    []string
+}
+
+func (bh *blockingHandler) Handle(ctx context.Context, r slog.Record) error {
+	<-bh.release
+	bh.mu.Lock()
+	defer bh.mu.Unlock()
+	bh.msgs = append(bh.msgs, r.Message)
+	return nil
+}
+
+func (bh *blockingHandler) messages() string {
+	bh.mu.Lock()
+	defer bh.mu.Unlock()
+	return strings.Join(bh.msgs, ",")
+}
+
+func (ah *AsyncHandler) inflight() bool {
+	ah.queue.mu.Lock()
+	defer ah.queue.mu.Unlock()
+	return uint64(len(ah.queue.entries)) < ah.queue.queued-ah.queue.forwarded
+}
+
+func (ah *AsyncHandler) withHandler(handler slog.Handler) *AsyncHandler {
+	return &AsyncHandler{handler: handler, queue: ah.queue}
+}
+
+func TestNewAsyncHandler(t *testing.T) {
+	ah := NewAsyncHandler(nil, WithAsyncQueueSize(0), WithAsyncBatchSize(0))
+	defer ah.Close(context.Background())
+
+	if ah.queue.size != 1024 || ah.queue.batch != 64 || ah.queue.policy != OverflowBlock {
+		t.Errorf("NewAsyncHandler() = %v, %v, %v, want %v, %v, %v", ah.queue.size, ah.queue.batch, ah.queue.policy, 1024, 64, OverflowBlock)
+	}
+	if !ah.Enabled(context.Background(), slog.LevelInfo) {
+		t.Errorf("AsyncHandler.Enabled() = false, want true")
+	}
+	if ah.WithAttrs(nil) != slog.Handler(ah) || ah.WithGroup("") != slog.Handler(ah) {
+		t.Errorf("AsyncHandler.WithAttrs() and AsyncHandler.WithGroup() must return the same handler")
+	}
+}
+
+func TestAsyncHandler_Handle_overflow(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      OverflowPolicy
+		want        string
+		wantDropped uint64
+	}{
+		{name: "block", policy: OverflowBlock, want: "1,2,3,4"},
+		{name: "drop newest", policy: OverflowDropNewest, want: "1,2,3", wantDropped: 1},
+		{name: "drop oldest", policy: OverflowDropOldest, want: "1,3,4", wantDropped: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bh := &blockingHandler{Handler: slog.Default().Handler(), release: make(chan struct{})}
+			ah := NewAsyncHandler(bh, WithAsyncQueueSize(2), WithAsyncBatchSize(1), WithAsyncOverflow(tt.policy))
+			l := slog.New(ah)
+
+			l.Info("1")
+			for !ah.inflight() {
+				time.Sleep(time.Millisecond)
+			}
+			l.Info("2")
+			l.Info("3")
+
+			logged := make(chan struct{})
+			go func() {
+				l.Info("4")
+				close(logged)
+			}()
+
+			if tt.policy == OverflowBlock {
+				select {
+				case <-logged:
+					t.Fatalf("AsyncHandler.Handle() did not block")
+				case <-time.After(20 * time.Millisecond):
+				}
+				close(bh.release)
+				<-logged
+			} else {
+				<-logged
+				close(bh.release)
+			}
+
+			if err := ah.Close(context.Background()); err != nil {
+				t.Fatalf("AsyncHandler.Close() error = %v", err)
+			}
+			if got := bh.messages(); got != tt.want {
+				t.Errorf("messages = %v, want %v", got, tt.want)
+			}
+			if got := ah.Dropped(); got != tt.wantDropped {
+				t.Errorf("AsyncHandler.Dropped() = %v, want %v", got, tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestAsyncHandler_Flush(t *testing.T) {
+	bh := &blockingHandler{Handler: slog.Default().Handler(), release: make(chan struct{})}
+	ah := NewAsyncHandler(bh)
+	defer ah.Close(context.Background())
+
+	l := slog.New(ah)
+	l.Info("1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := ah.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("AsyncHandler.Flush() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	close(bh.release)
+	if err := ah.Flush(context.Background()); err != nil {
+		t.Fatalf("AsyncHandler.Flush() error = %v", err)
+	}
+	if got := bh.messages(); got != "1" {
+		t.Errorf("messages = %v, want %v", got, "1")
+	}
+}
+
+func TestAsyncHandler_Flush_queuedAfter(t *testing.T) {
+	first := &blockingHandler{Handler: slog.Default().Handler(), release: make(chan struct{})}
+	later := &blockingHandler{Handler: slog.Default().Handler(), release: make(chan struct{})}
+	ah := NewAsyncHandler(first, WithAsyncBatchSize(1))
+	defer ah.Close(context.Background())
+	defer close(later.release)
+
+	slog.New(ah).Info("1")
+
+	flushed := make(chan error)
+	go func() {
+		flushed <- ah.Flush(context.Background())
+	}()
+
+	// The record queued after the call blocks the downstream handler until the end of the test.
+	time.Sleep(20 * time.Millisecond)
+	slog.New(ah.withHandler(later)).Info("2")
+	close(first.release)
+
+	select {
+	case err := <-flushed:
+		if err != nil {
+			t.Fatalf("AsyncHandler.Flush() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("AsyncHandler.Flush() waited for the record queued after the call")
+	}
+	if got := first.messages(); got != "1" {
+		t.Errorf("messages = %v, want %v", got, "1")
+	}
+}
+
+func TestAsyncHandler_Close(t *testing.T) {
+	bh := &blockingHandler{Handler: slog.Default().Handler(), release: make(chan struct{})}
+	ah := NewAsyncHandler(bh)
+	l := slog.New(ah)
+
+	reqCtx, reqCancel := context.WithCancel(context.Background())
+	l.InfoContext(reqCtx, "1")
+	reqCancel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := ah.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("AsyncHandler.Close() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	close(bh.release)
+	if err := ah.Close(context.Background()); err != nil {
+		t.Fatalf("AsyncHandler.Close() error = %v", err)
+	}
+
+	l.Info("2")
+	if got := bh.messages(); got != "1,2" {
+		t.Errorf("messages = %v, want %v", got, "1,2")
+	}
+}
+
+func TestAsyncHandler_Close_order(t *testing.T) {
+	bh := &blockingHandler{Handler: slog.Default().Handler(), release: make(chan struct{})}
+	ah := NewAsyncHandler(bh, WithAsyncBatchSize(1))
+	l := slog.New(ah)
+
+	l.Info("1")
+	l.Info("2")
+
+	closed := make(chan error)
+	go func() {
+		closed <- ah.Close(context.Background())
+	}()
+	for {
+		ah.queue.mu.Lock()
+		c := ah.queue.closed
+		ah.queue.mu.Unlock()
+		if c {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// The record logged while the queue is drained is queued after the previous records.
+	l.Info("3")
+	close(bh.release)
+
+	if err := <-closed; err != nil {
+		t.Fatalf("AsyncHandler.Close() error = %v", err)
+	}
+	l.Info("4")
+	if got := bh.messages(); got != "1,2,3,4" {
+		t.Errorf("messages = %v, want %v", got, "1,2,3,4")
+	}
+}
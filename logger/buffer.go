@@ -0,0 +1,108 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+type (
+	// logBuffer holds the records of a request below the minimum log level in a ring buffer,
+	// until an error is logged in the same context.
+	logBuffer struct {
+		mu      sync.Mutex
+		entries []bufferedRecord
+		next    int
+		full    bool
+		flushed bool
+	}
+
+	bufferedRecord struct {
+		ctx     context.Context
+		handler slog.Handler
+		record  slog.Record
+	}
+)
+
+// WithDebugOnError is an [Option] that buffers the records below the minimum log level instead of discarding them,
+// logging them only when the request fails.
+// The size defines the maximum number of records buffered for each context, zero disables the buffer.
+//
+// Behavior:
+//   - Only contexts with a buffer added by [LogBuffer] are buffered, other records below the minimum level are discarded.
+//   - The buffer keeps the last records, the oldest records are discarded when it is full.
+//   - When a record at or above [slog.LevelError] is logged in the context, the buffered records are logged before it in order.
+//   - The buffer can be flushed explicitly with [FlushLogBuffer], such as when the response status is 5xx.
+//   - After the buffer is flushed, the records below the minimum level of the same context are logged directly.
+//   - Otherwise the buffer is discarded with the context when the request ends.
+//
+// Important Note:
+//   - In a context with a buffer every level is enabled, so the records below the minimum level are built,
+//     prepared and copied into the buffer, paying the cost of a logged record even if they are discarded.
+//     Records with a high cost in hot paths, like payloads at [LevelTrace], should be guarded by the caller.
+//   - The entries of the buffer are allocated on the first buffered record,
+//     handlers created without [WithDebugOnError] ignore the buffer of the context.
+func WithDebugOnError(size int) Option {
+	return func(lh *loggerHandler) {
+		lh.bufferSize = max(size, 0)
+	}
+}
+
+// FlushLogBuffer logs the records buffered by [WithDebugOnError] in the context,
+// and the following records below the minimum level of the same context are logged directly.
+// It returns the errors of the handlers, joined.
+func FlushLogBuffer(ctx context.Context) error {
+	if lb, ok := ctx.Value(ContextLogBuffer).(*logBuffer); ok {
+		return lb.flush()
+	}
+	return nil
+}
+
+// add appends the record created by entry to the ring buffer, reporting whether it was buffered.
+// The check and the append are done under the lock, so a record is never lost by a concurrent flush:
+// if the buffer was already flushed, it returns false without calling entry and the record must be logged directly.
+func (lb *logBuffer) add(size int, entry func() bufferedRecord) bool {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	if lb.flushed {
+		return false
+	}
+
+	if lb.entries == nil {
+		lb.entries = make([]bufferedRecord, size)
+	}
+
+	lb.entries[lb.next] = entry()
+	lb.next = (lb.next + 1) % len(lb.entries)
+	if lb.next == 0 {
+		lb.full = true
+	}
+	return true
+}
+
+func (lb *logBuffer) flush() error {
+	lb.mu.Lock()
+	if lb.flushed {
+		lb.mu.Unlock()
+		return nil
+	}
+	lb.flushed = true
+
+	entries := lb.entries[:lb.next]
+	if lb.full {
+		entries = slices.Concat(lb.entries[lb.next:], lb.entries[:lb.next])
+	}
+	lb.entries = nil
+	lb.mu.Unlock()
+
+	var errs []error
+	for _, br := range entries {
+		if err := br.handler.Handle(br.ctx, br.record); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
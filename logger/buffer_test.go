@@ -0,0 +1,151 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWithDebugOnError(t *testing.T) {
+	type args struct {
+		size     int
+		buffer   bool
+		messages []slog.Level
+		flush    bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "without buffer",
+			args: args{buffer: true, messages: []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelError}},
+			want: "INFO 1,ERROR 2",
+		},
+		{
+			name: "without log id",
+			args: args{size: 10, messages: []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelError}},
+			want: "INFO 1,ERROR 2",
+		},
+		{
+			name: "request succeeds",
+			args: args{size: 10, buffer: true, messages: []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelDebug}},
+			want: "INFO 1",
+		},
+		{
+			name: "error flushes",
+			args: args{size: 10, buffer: true, messages: []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelDebug, slog.LevelError, slog.LevelDebug}},
+			want: "INFO 1,DEBUG 0,DEBUG 2,ERROR 3,DEBUG 4",
+		},
+		{
+			name: "ring buffer",
+			args: args{size: 2, buffer: true, messages: []slog.Level{slog.LevelDebug, slog.LevelDebug, slog.LevelDebug, slog.LevelError}},
+			want: "DEBUG 1,DEBUG 2,ERROR 3",
+		},
+		{
+			name: "explicit flush",
+			args: args{size: 10, buffer: true, messages: []slog.Level{slog.LevelDebug, slog.LevelWarn}, flush: true},
+			want: "WARN 1,DEBUG 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := NewLogger(
+				WithDebugOnError(tt.args.size),
+				WithMaxLevelAddSource(slog.LevelDebug-1),
+				WithHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+					Level: slog.LevelDebug,
+					ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+						if a.Key != slog.LevelKey && a.Key != slog.MessageKey {
+							return slog.Attr{}
+						}
+						return a
+					},
+				})),
+			)
+
+			ctx := context.Background()
+			if tt.args.buffer {
+				ctx = LogBuffer(ctx)
+			}
+
+			for i, level := range tt.args.messages {
+				l.Log(ctx, level, string(rune('0'+i)))
+			}
+			if tt.args.flush {
+				if err := FlushLogBuffer(ctx); err != nil {
+					t.Fatalf("FlushLogBuffer() error = %v", err)
+				}
+			}
+
+			got := strings.ReplaceAll(strings.TrimSpace(buf.String()), "\n", ",")
+			got = strings.NewReplacer("level=", "", "msg=", "").Replace(got)
+			if got != tt.want {
+				t.Errorf("log = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlushLogBuffer(t *testing.T) {
+	if err := FlushLogBuffer(context.Background()); err != nil {
+		t.Errorf("FlushLogBuffer() error = %v, want nil", err)
+	}
+}
+
+func Test_logBuffer_add(t *testing.T) {
+	lb := &logBuffer{}
+	entry := func() bufferedRecord { return bufferedRecord{handler: slog.NewTextHandler(io.Discard, nil)} }
+
+	if !lb.add(2, entry) {
+		t.Errorf("logBuffer.add() = false, want true")
+	}
+	if err := lb.flush(); err != nil {
+		t.Fatalf("logBuffer.flush() error = %v", err)
+	}
+	if lb.add(2, func() bufferedRecord { t.Errorf("entry called after flush"); return entry() }) {
+		t.Errorf("logBuffer.add() after flush = true, want false")
+	}
+}
+
+func TestWithDebugOnError_concurrentFlush(t *testing.T) {
+	const records = 100
+
+	var (
+		mu  sync.Mutex
+		buf strings.Builder
+	)
+	l := NewLogger(
+		WithDebugOnError(records),
+		WithHandler(slog.NewTextHandler(&lockedWriter{mu: &mu, w: &buf}, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	)
+	ctx := LogBuffer(context.Background())
+
+	var wg sync.WaitGroup
+	for range records {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.DebugContext(ctx, "debug")
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = FlushLogBuffer(ctx)
+	}()
+	wg.Wait()
+	_ = FlushLogBuffer(ctx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got := strings.Count(buf.String(), "msg=debug"); got != records {
+		t.Errorf("logged = %v, want %v", got, records)
+	}
+}
@@ -0,0 +1,202 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrInvalidConfig is returned by [FromEnv] and [ParseConfig] when a setting is unknown or its value is not valid.
+var ErrInvalidConfig = errors.New("invalid log config")
+
+// levelAll is lower than any level, it is used to disable the level of the inner handlers and the source code tracing.
+const levelAll = slog.Level(math.MinInt)
+
+var configKeys = []string{"level", "format", "output", "reopen", "add_source", "rules", "sampling"}
+
+// FromEnv returns a new handler created with [NewHandler] from the environment variables,
+// and the function closing its output.
+// A variadic set of [Option] applied after the configuration.
+//
+// Environment variables:
+//   - LOG_LEVEL: The minimum log level, one of the levels accepted by [MinLevel]. Default is info.
+//   - LOG_FORMAT: The format, text, json or dev. The dev format uses [NewConsoleHandler]. Default is text.
+//   - LOG_OUTPUT: The output, stdout, stderr or a file path opened with [NewRotatingFile]. Default is stdout.
+//   - LOG_REOPEN: Whether the file output is closed and opened again on SIGHUP, so it can be moved by logrotate,
+//     true or false. Default is false.
+//   - LOG_ADD_SOURCE: The maximum log level at which source code information is added, a level or off. Default is debug.
+//   - LOG_RULES: The [LevelRules], like billing=debug,github.com/foo=warn.
+//   - LOG_SAMPLING: The sampling [WithSampling] as first/thereafter/interval, like 100/100/1s, or off. Default is off.
+//
+// Important Note:
+//   - The close function must be called before the application exits, it closes the file output with [RotatingFile.Close].
+//     For stdout and stderr it does nothing.
+//   - The SIGHUP handler is installed only with LOG_REOPEN, the signal is process-wide and stops the default termination on SIGHUP.
+//
+// Example:
+//
+//	h, closeLog, err := logger.FromEnv()
+//	if err != nil {
+//		return err
+//	}
+//	defer closeLog(context.Background())
+//	slog.SetDefault(slog.New(h))
+func FromEnv(opts ...Option) (slog.Handler, func(context.Context) error, error) {
+	return newConfigHandler(func(key string) (string, string) {
+		name := "LOG_" + strings.ToUpper(key)
+		return name, os.Getenv(name)
+	}, opts)
+}
+
+// ParseConfig returns a new handler created with [NewHandler] from the settings separated by spaces, like
+// "level=debug format=json rules=billing=debug", and the function closing its output.
+// The settings are the same as the environment variables of [FromEnv] in lower case, without the LOG_ prefix.
+// A variadic set of [Option] applied after the configuration.
+func ParseConfig(config string, opts ...Option) (slog.Handler, func(context.Context) error, error) {
+	settings := map[string]string{}
+	for _, field := range strings.Fields(config) {
+		key, value, ok := strings.Cut(field, "=")
+		key = strings.ToLower(key)
+		if !ok || !slices.Contains(configKeys, key) {
+			return nil, nil, fmt.Errorf("%w: unknown setting %q, the settings are %s", ErrInvalidConfig, field, strings.Join(configKeys, ", "))
+		}
+		settings[key] = value
+	}
+
+	return newConfigHandler(func(key string) (string, string) {
+		return key, settings[key]
+	}, opts)
+}
+
+// noClose is the close function of the outputs that are not closed, like stdout.
+func noClose(context.Context) error {
+	return nil
+}
+
+func newConfigHandler(get func(key string) (name, value string), opts []Option) (slog.Handler, func(context.Context) error, error) {
+	var configOpts []Option
+
+	if name, value := get("level"); value != "" {
+		level, err := parseLevel(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+		configOpts = append(configOpts, WithMinLevel(level))
+	}
+
+	if name, value := get("add_source"); value != "" {
+		level := levelAll
+		if !strings.EqualFold(value, "off") {
+			var err error
+			if level, err = parseLevel(value); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		configOpts = append(configOpts, WithMaxLevelAddSource(level))
+	}
+
+	if name, value := get("rules"); value != "" {
+		rules, err := ParseLevelRules(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+		configOpts = append(configOpts, WithLevelRules(rules))
+	}
+
+	if name, value := get("sampling"); value != "" && !strings.EqualFold(value, "off") {
+		sampling, err := parseSampling(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+		configOpts = append(configOpts, WithSampling(sampling...))
+	}
+
+	var newHandler func(w io.Writer) slog.Handler
+	name, format := get("format")
+	switch strings.ToLower(format) {
+	case "", "text":
+		newHandler = func(w io.Writer) slog.Handler {
+			return slog.NewTextHandler(w, &slog.HandlerOptions{Level: levelAll, ReplaceAttr: ReplaceLevelNames})
+		}
+	case "json":
+		newHandler = func(w io.Writer) slog.Handler {
+			return slog.NewJSONHandler(w, &slog.HandlerOptions{Level: levelAll, ReplaceAttr: ReplaceLevelNames})
+		}
+	case "dev":
+		newHandler = func(w io.Writer) slog.Handler {
+			return NewConsoleHandler(w, WithConsoleLevel(levelAll))
+		}
+	default:
+		return nil, nil, fmt.Errorf("%s: %w: format %q must be text, json or dev", name, ErrInvalidConfig, format)
+	}
+
+	var reopenSignals []os.Signal
+	if name, value := get("reopen"); value != "" {
+		reopen, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w: reopen %q must be true or false", name, ErrInvalidConfig, value)
+		}
+		if reopen {
+			reopenSignals = []os.Signal{syscall.SIGHUP}
+		}
+	}
+
+	var w io.Writer
+	closeOutput := noClose
+	name, output := get("output")
+	switch strings.ToLower(output) {
+	case "", "stdout":
+		w = os.Stdout
+	case "stderr":
+		w = os.Stderr
+	default:
+		rf, err := NewRotatingFile(output, WithReopenSignals(reopenSignals...))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+		w = rf
+		closeOutput = rf.Close
+	}
+	configOpts = append(configOpts, WithHandler(newHandler(w)))
+
+	return NewHandler(append(configOpts, opts...)...), closeOutput, nil
+}
+
+// parseSampling parses first/thereafter/interval, the interval is optional.
+func parseSampling(value string) ([]OptionSampling, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, fmt.Errorf("%w: sampling %q must be first/thereafter/interval, like 100/100/1s", ErrInvalidConfig, value)
+	}
+
+	first, err := strconv.Atoi(parts[0])
+	if err != nil || first < 0 {
+		return nil, fmt.Errorf("%w: sampling first %q must be a non-negative number", ErrInvalidConfig, parts[0])
+	}
+
+	thereafter, err := strconv.Atoi(parts[1])
+	if err != nil || thereafter < 0 {
+		return nil, fmt.Errorf("%w: sampling thereafter %q must be a non-negative number", ErrInvalidConfig, parts[1])
+	}
+
+	opts := []OptionSampling{WithSampleRate(first, thereafter)}
+
+	if len(parts) == 3 {
+		interval, err := time.ParseDuration(parts[2])
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("%w: sampling interval %q must be a positive duration, like 1s", ErrInvalidConfig, parts[2])
+		}
+		opts = append(opts, WithSampleInterval(interval))
+	}
+
+	return opts, nil
+}
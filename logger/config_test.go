@@ -0,0 +1,180 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseConfig(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "file"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	type want struct {
+		level             slog.Level
+		maxLevelAddSource slog.Level
+		rules             string
+		sampling          bool
+		handler           string
+		closeFile         bool
+		err               error
+		errMsg            string
+	}
+	tests := []struct {
+		name string
+		args string
+		want want
+	}{
+		{
+			name: "default",
+			want: want{level: slog.LevelInfo, maxLevelAddSource: slog.LevelDebug, handler: "*slog.TextHandler"},
+		},
+		{
+			name: "all settings",
+			args: "level=debug format=json output=stderr add_source=warn rules=billing=debug,github.com/foo=warn sampling=10/100/1s",
+			want: want{
+				level:             slog.LevelDebug,
+				maxLevelAddSource: slog.LevelWarn,
+				rules:             "billing=debug,github.com/foo=warn",
+				sampling:          true,
+				handler:           "*slog.JSONHandler",
+			},
+		},
+		{
+			name: "dev format and file output",
+			args: "FORMAT=dev output=" + filepath.Join(dir, "app.log") + " add_source=off sampling=off",
+			want: want{level: slog.LevelInfo, maxLevelAddSource: levelAll, handler: "*slog.JSONHandler", closeFile: true},
+		},
+		{
+			name: "file output reopened on signal",
+			args: "output=" + filepath.Join(dir, "reopen.log") + " reopen=true",
+			want: want{level: slog.LevelInfo, maxLevelAddSource: slog.LevelDebug, handler: "*slog.TextHandler", closeFile: true},
+		},
+		{
+			name: "sampling without interval",
+			args: "sampling=1/0",
+			want: want{level: slog.LevelInfo, maxLevelAddSource: slog.LevelDebug, sampling: true, handler: "*slog.TextHandler"},
+		},
+		{
+			name: "unknown setting",
+			args: "color=true",
+			want: want{err: ErrInvalidConfig, errMsg: `invalid log config: unknown setting "color=true", the settings are level, format, output, reopen, add_source, rules, sampling`},
+		},
+		{
+			name: "without value",
+			args: "level",
+			want: want{err: ErrInvalidConfig},
+		},
+		{
+			name: "invalid level",
+			args: "level=verbose",
+			want: want{err: ErrInvalidMinLevel, errMsg: `level: invalid min level: "verbose" must be trace, debug, info, notice, warn, error, fatal or a number`},
+		},
+		{
+			name: "invalid add source",
+			args: "add_source=all",
+			want: want{err: ErrInvalidMinLevel},
+		},
+		{
+			name: "invalid rules",
+			args: "rules=billing",
+			want: want{err: ErrInvalidLevelRules},
+		},
+		{
+			name: "invalid format",
+			args: "format=xml",
+			want: want{err: ErrInvalidConfig, errMsg: `format: invalid log config: format "xml" must be text, json or dev`},
+		},
+		{
+			name: "invalid reopen",
+			args: "reopen=sometimes",
+			want: want{err: ErrInvalidConfig, errMsg: `reopen: invalid log config: reopen "sometimes" must be true or false`},
+		},
+		{
+			name: "invalid output",
+			args: "output=" + filepath.Join(dir, "file", "app.log"),
+			want: want{errMsg: "output: logger: creating log directory: mkdir " + filepath.Join(dir, "file") + ": not a directory"},
+		},
+		{name: "invalid sampling", args: "sampling=10", want: want{err: ErrInvalidConfig}},
+		{name: "invalid sampling first", args: "sampling=a/10", want: want{err: ErrInvalidConfig}},
+		{name: "invalid sampling thereafter", args: "sampling=10/-1", want: want{err: ErrInvalidConfig}},
+		{name: "invalid sampling interval", args: "sampling=10/10/0s", want: want{err: ErrInvalidConfig}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, closeOutput, err := ParseConfig(tt.args)
+			if tt.want.err != nil || tt.want.errMsg != "" {
+				if tt.want.err != nil && !errors.Is(err, tt.want.err) {
+					t.Fatalf("ParseConfig() error = %v, want %v", err, tt.want.err)
+				}
+				if tt.want.errMsg != "" && (err == nil || err.Error() != tt.want.errMsg) {
+					t.Fatalf("ParseConfig() error = %v, want %v", err, tt.want.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseConfig() error = %v", err)
+			}
+			if err := closeOutput(context.Background()); err != nil {
+				t.Fatalf("close error = %v", err)
+			}
+
+			lh := h.(*loggerHandler)
+			if got := lh.level.Level(); got != tt.want.level {
+				t.Errorf("level = %v, want %v", got, tt.want.level)
+			}
+			if lh.maxLevelAddSource != tt.want.maxLevelAddSource {
+				t.Errorf("maxLevelAddSource = %v, want %v", lh.maxLevelAddSource, tt.want.maxLevelAddSource)
+			}
+			if got := lh.level.Rules().String(); got != tt.want.rules {
+				t.Errorf("rules = %v, want %v", got, tt.want.rules)
+			}
+			if (lh.sampler != nil) != tt.want.sampling {
+				t.Errorf("sampling = %v, want %v", lh.sampler != nil, tt.want.sampling)
+			}
+			if got := fmt.Sprintf("%T", lh.handler); got != tt.want.handler {
+				t.Errorf("handler = %v, want %v", got, tt.want.handler)
+			}
+			if !lh.handler.Enabled(context.Background(), slog.LevelDebug-4) {
+				t.Errorf("handler.Enabled() = false, want true")
+			}
+			if tt.want.closeFile {
+				r := slog.NewRecord(time.Now(), slog.LevelInfo, "message", 0)
+				if err := lh.handler.Handle(context.Background(), r); !errors.Is(err, os.ErrClosed) {
+					t.Errorf("handler.Handle() error = %v, want %v", err, os.ErrClosed)
+				}
+			}
+		})
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("LOG_FORMAT", "json")
+
+	h, closeOutput, err := FromEnv(WithName("billing"))
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+	defer closeOutput(context.Background())
+
+	lh := h.(*loggerHandler)
+	if got := lh.level.Level(); got != slog.LevelWarn {
+		t.Errorf("level = %v, want %v", got, slog.LevelWarn)
+	}
+	if lh.name != "billing" {
+		t.Errorf("name = %v, want %v", lh.name, "billing")
+	}
+
+	t.Setenv("LOG_LEVEL", "verbose")
+	if _, _, err := FromEnv(); err == nil || err.Error() != `LOG_LEVEL: invalid min level: "verbose" must be trace, debug, info, notice, warn, error, fatal or a number` {
+		t.Errorf("FromEnv() error = %v", err)
+	}
+}
@@ -0,0 +1,340 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+type (
+	consoleHandler struct {
+		mu         *sync.Mutex
+		w          io.Writer
+		level      slog.Leveler
+		timeFormat string
+		color      bool
+		force      bool
+		groups     []groupOrAttrs
+	}
+
+	// OptionConsole is used to apply configurations to the console handler when creating it with [NewConsoleHandler].
+	OptionConsole func(*consoleHandler)
+)
+
+const (
+	consoleMessageWidth = 40
+
+	colorReset   = "\x1b[0m"
+	colorFaint   = "\x1b[2m"
+	colorRed     = "\x1b[31m"
+	colorGreen   = "\x1b[32m"
+	colorYellow  = "\x1b[33m"
+	colorBlue    = "\x1b[34m"
+	colorMagenta = "\x1b[35m"
+	colorCyan    = "\x1b[36m"
+)
+
+// NewConsoleHandler returns a new [slog.Handler] writing human-friendly log entries for local development.
+// A variadic set of [OptionConsole] used to configure the handler.
+//
+// Behavior:
+//   - Levels are colored and abbreviated, like INF and ERR, including the custom levels TRC, NTC and FTL.
+//   - Timestamps are compact, messages are aligned and the source code attribute is shortened to dir/file.go:line.
+//   - Groups such as request and response from MiddlewareLogging are printed on their own indented lines.
+//   - If the writer is not a terminal, the handler falls back to [slog.NewJSONHandler] with [ReplaceLevelNames].
+//
+// Default:
+//   - The minimum level is [slog.LevelInfo], see [WithConsoleLevel].
+//   - The time format is 15:04:05.000.
+//   - Colors are enabled, unless the NO_COLOR environment variable is set.
+//
+// Example:
+//
+//	slog.SetDefault(logger.NewLogger(
+//		logger.WithMinLevel(slog.LevelDebug),
+//		logger.WithHandler(logger.NewConsoleHandler(os.Stdout)),
+//	))
+func NewConsoleHandler(w io.Writer, opts ...OptionConsole) slog.Handler {
+	ch := &consoleHandler{
+		mu:         &sync.Mutex{},
+		w:          w,
+		level:      slog.LevelInfo,
+		timeFormat: "15:04:05.000",
+		color:      os.Getenv("NO_COLOR") == "",
+	}
+
+	for _, opt := range opts {
+		opt(ch)
+	}
+
+	if !ch.force && !isTerminal(w) {
+		return slog.NewJSONHandler(w, &slog.HandlerOptions{Level: ch.level, ReplaceAttr: ReplaceLevelNames})
+	}
+
+	return ch
+}
+
+// WithConsoleLevel is an [OptionConsole] that defines the minimum level of the console handler.
+//
+// Important Note:
+//   - The level only applies when the console handler is used standalone, with [slog.New] or as a [Sink] without [WithSinkLevel].
+//     With [NewHandler] and [WithHandler], the level is defined only by [WithMinLevel] and the [LevelController].
+func WithConsoleLevel(level slog.Leveler) OptionConsole {
+	return func(ch *consoleHandler) {
+		if level != nil {
+			ch.level = level
+		}
+	}
+}
+
+// WithConsoleTimeFormat is an [OptionConsole] that defines the layout of the timestamp, an empty layout omits it.
+func WithConsoleTimeFormat(layout string) OptionConsole {
+	return func(ch *consoleHandler) {
+		ch.timeFormat = layout
+	}
+}
+
+// WithConsoleColor is an [OptionConsole] that enables or disables the colors.
+func WithConsoleColor(enabled bool) OptionConsole {
+	return func(ch *consoleHandler) {
+		ch.color = enabled
+	}
+}
+
+// WithConsoleForce is an [OptionConsole] that keeps the console format when the writer is not a terminal,
+// such as when the output is piped to a pager.
+func WithConsoleForce(force bool) OptionConsole {
+	return func(ch *consoleHandler) {
+		ch.force = force
+	}
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func (ch *consoleHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return l >= ch.level.Level()
+}
+
+func (ch *consoleHandler) paint(buf *bytes.Buffer, color, s string) {
+	if ch.color && color != "" {
+		buf.WriteString(color)
+		buf.WriteString(s)
+		buf.WriteString(colorReset)
+		return
+	}
+	buf.WriteString(s)
+}
+
+func consoleLevel(l slog.Level) (string, string) {
+	switch {
+	case l < slog.LevelDebug:
+		return "TRC", colorFaint
+	case l < slog.LevelInfo:
+		return "DBG", colorBlue
+	case l < LevelNotice:
+		return "INF", colorGreen
+	case l < slog.LevelWarn:
+		return "NTC", colorCyan
+	case l < slog.LevelError:
+		return "WRN", colorYellow
+	case l < LevelFatal:
+		return "ERR", colorRed
+	default:
+		return "FTL", colorMagenta
+	}
+}
+
+func (ch *consoleHandler) Handle(ctx context.Context, r slog.Record) error {
+	buf := &bytes.Buffer{}
+
+	if ch.timeFormat != "" && !r.Time.IsZero() {
+		ch.paint(buf, colorFaint, r.Time.Format(ch.timeFormat))
+		buf.WriteByte(' ')
+	}
+
+	level, color := consoleLevel(r.Level)
+	ch.paint(buf, color, level)
+	buf.WriteByte(' ')
+
+	msg := consoleMessage(r.Message)
+	buf.WriteString(msg)
+
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	attrs = nestAttrs(ch.groups, attrs)
+
+	inline := &bytes.Buffer{}
+	var source string
+	var groups []slog.Attr
+	for _, a := range attrs {
+		a.Value = a.Value.Resolve()
+		switch {
+		case a.Equal(slog.Attr{}):
+		case a.Key == "source" && a.Value.Kind() == slog.KindGroup:
+			source = consoleSource(a.Value.Group())
+		case a.Value.Kind() == slog.KindGroup:
+			groups = append(groups, a)
+		default:
+			inline.WriteByte(' ')
+			ch.writeAttr(inline, a)
+		}
+	}
+
+	if source != "" {
+		inline.WriteByte(' ')
+		ch.paint(inline, colorFaint, source)
+	}
+
+	if inline.Len() > 0 {
+		if pad := consoleMessageWidth - utf8.RuneCountInString(msg); pad > 0 {
+			buf.WriteString(strings.Repeat(" ", pad))
+		}
+		buf.Write(inline.Bytes())
+	}
+	buf.WriteByte('\n')
+
+	for _, g := range groups {
+		ch.writeGroup(buf, g, 1)
+	}
+
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	_, err := ch.w.Write(buf.Bytes())
+	return err
+}
+
+// consoleSource returns the source code attribute added by [NewHandler] shortened to dir/file.go:line.
+func consoleSource(attrs []slog.Attr) string {
+	var file string
+	var line int64
+	for _, a := range attrs {
+		switch a.Key {
+		case "file":
+			file = a.Value.String()
+		case "line":
+			line = a.Value.Int64()
+		}
+	}
+	if file == "" {
+		return ""
+	}
+
+	dir, base := filepath.Split(file)
+	return fmt.Sprintf("%s:%d", filepath.ToSlash(filepath.Join(filepath.Base(dir), base)), line)
+}
+
+func (ch *consoleHandler) writeAttr(buf *bytes.Buffer, a slog.Attr) {
+	ch.paint(buf, colorCyan, consoleMessage(a.Key)+"=")
+	buf.WriteString(consoleValue(a.Value))
+}
+
+// writeGroup writes the group on its own line, the nested groups are written on the following lines with more indentation.
+func (ch *consoleHandler) writeGroup(buf *bytes.Buffer, g slog.Attr, depth int) {
+	buf.WriteString(strings.Repeat("  ", depth))
+	ch.paint(buf, colorFaint, consoleMessage(g.Key)+":")
+
+	var nested []slog.Attr
+	for _, a := range g.Value.Group() {
+		a.Value = a.Value.Resolve()
+		switch {
+		case a.Equal(slog.Attr{}):
+		case a.Value.Kind() == slog.KindGroup:
+			nested = append(nested, a)
+		default:
+			buf.WriteByte(' ')
+			ch.writeAttr(buf, a)
+		}
+	}
+	buf.WriteByte('\n')
+
+	for _, n := range nested {
+		ch.writeGroup(buf, n, depth+1)
+	}
+}
+
+// consoleMessage returns the message with the control characters escaped, like \n and \x1b,
+// so a message or a key can not break the line or write terminal escape sequences.
+func consoleMessage(msg string) string {
+	if strings.IndexFunc(msg, func(r rune) bool { return !unicode.IsPrint(r) }) < 0 {
+		return msg
+	}
+
+	var sb strings.Builder
+	for _, r := range msg {
+		if unicode.IsPrint(r) {
+			sb.WriteRune(r)
+			continue
+		}
+		q := strconv.QuoteRune(r)
+		sb.WriteString(q[1 : len(q)-1])
+	}
+	return sb.String()
+}
+
+func consoleValue(v slog.Value) string {
+	var s string
+	switch v.Kind() {
+	case slog.KindTime:
+		s = v.Time().Format(time.RFC3339Nano)
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			s = err.Error()
+		} else {
+			s = fmt.Sprintf("%+v", v.Any())
+		}
+	default:
+		s = v.String()
+	}
+
+	if s == "" || strings.IndexFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '"' || r == '=' || !unicode.IsPrint(r)
+	}) >= 0 {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func (ch *consoleHandler) clone() *consoleHandler {
+	c := *ch
+	c.groups = slices.Clip(ch.groups)
+	return &c
+}
+
+func (ch *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return ch
+	}
+	c := ch.clone()
+	c.groups = append(c.groups, groupOrAttrs{attrs: attrs})
+	return c
+}
+
+func (ch *consoleHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return ch
+	}
+	c := ch.clone()
+	c.groups = append(c.groups, groupOrAttrs{group: name})
+	return c
+}
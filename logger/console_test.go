@@ -0,0 +1,232 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewConsoleHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	tests := []struct {
+		name     string
+		opts     []OptionConsole
+		wantJSON bool
+	}{
+		{name: "not a terminal", wantJSON: true},
+		{name: "force", opts: []OptionConsole{WithConsoleForce(true)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, gotJSON := NewConsoleHandler(&buf, tt.opts...).(*slog.JSONHandler)
+			if gotJSON != tt.wantJSON {
+				t.Errorf("NewConsoleHandler() JSON = %v, want %v", gotJSON, tt.wantJSON)
+			}
+		})
+	}
+}
+
+func TestNewConsoleHandler_jsonLevelNames(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewConsoleHandler(&buf, WithConsoleLevel(LevelTrace))
+
+	if err := h.Handle(context.Background(), slog.NewRecord(time.Time{}, LevelTrace, "message", 0)); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, `"level":"TRACE"`) {
+		t.Errorf("log = %v, want level TRACE", got)
+	}
+}
+
+func Test_isTerminal(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if isTerminal(f) {
+		t.Errorf("isTerminal() = true, want false")
+	}
+	if isTerminal(&bytes.Buffer{}) {
+		t.Errorf("isTerminal() = true, want false")
+	}
+}
+
+func Test_consoleHandler_Handle(t *testing.T) {
+	tm := time.Date(2024, 1, 2, 10, 30, 15, 123000000, time.UTC)
+
+	type args struct {
+		opts  []OptionConsole
+		level slog.Level
+		msg   string
+		attrs []slog.Attr
+		with  func(slog.Handler) slog.Handler
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "message",
+			args: args{level: slog.LevelInfo, msg: "message"},
+			want: "10:30:15.123 INF message\n",
+		},
+		{
+			name: "control characters",
+			args: args{level: slog.LevelInfo, msg: "line\n10:30:16.000 ERR \x1b[31mforged"},
+			want: `10:30:15.123 INF line\n10:30:16.000 ERR \x1b[31mforged` + "\n",
+		},
+		{
+			name: "control characters in keys",
+			args: args{
+				level: slog.LevelInfo,
+				msg:   strings.Repeat("m", 40),
+				attrs: []slog.Attr{slog.Int("a\nb", 1), slog.Group("\x1b[31mg", slog.Int("n", 1))},
+			},
+			want: "10:30:15.123 INF " + strings.Repeat("m", 40) + ` a\nb=1` + "\n" +
+				`  \x1b[31mg: n=1` + "\n",
+		},
+		{
+			name: "aligned multi-byte message",
+			args: args{level: slog.LevelInfo, msg: "mensagem não", attrs: []slog.Attr{slog.Int("n", 1)}},
+			want: "10:30:15.123 INF mensagem não" + strings.Repeat(" ", 28) + " n=1\n",
+		},
+		{
+			name: "aligned message",
+			args: args{level: slog.LevelInfo, msg: "message", attrs: []slog.Attr{slog.Int("n", 1)}},
+			want: "10:30:15.123 INF message" + strings.Repeat(" ", 33) + " n=1\n",
+		},
+		{
+			name: "attrs",
+			args: args{
+				level: slog.LevelWarn,
+				msg:   strings.Repeat("m", 40),
+				attrs: []slog.Attr{
+					slog.String("a", "b c"),
+					slog.Int("n", 1),
+					slog.Any("err", errors.New("failed")),
+					{},
+				},
+			},
+			want: "10:30:15.123 WRN " + strings.Repeat("m", 40) + ` a="b c" n=1 err=failed` + "\n",
+		},
+		{
+			name: "groups and source",
+			args: args{
+				level: slog.LevelError,
+				msg:   strings.Repeat("m", 40),
+				attrs: []slog.Attr{
+					slog.Group("request", slog.String("method", "GET"), slog.Group("user", slog.String("ip", "127.0.0.1"))),
+					slog.Group("source", slog.String("function", "main.main"), slog.String("file", "/app/cmd/main.go"), slog.Int("line", 42)),
+					slog.String("logger", "billing"),
+				},
+			},
+			want: "10:30:15.123 ERR " + strings.Repeat("m", 40) + " logger=billing cmd/main.go:42\n" +
+				"  request: method=GET\n" +
+				"    user: ip=127.0.0.1\n",
+		},
+		{
+			name: "with attrs and group",
+			args: args{
+				opts:  []OptionConsole{WithConsoleTimeFormat("")},
+				level: slog.LevelDebug - 4,
+				msg:   strings.Repeat("m", 40),
+				attrs: []slog.Attr{slog.Int("n", 1)},
+				with: func(h slog.Handler) slog.Handler {
+					return h.WithAttrs([]slog.Attr{slog.String("a", "b")}).WithGroup("g").WithAttrs(nil).WithGroup("")
+				},
+			},
+			want: "TRC " + strings.Repeat("m", 40) + " a=b\n" +
+				"  g: n=1\n",
+		},
+		{
+			name: "color",
+			args: args{
+				opts:  []OptionConsole{WithConsoleColor(true), WithConsoleTimeFormat("")},
+				level: slog.LevelDebug,
+				msg:   strings.Repeat("m", 40),
+				attrs: []slog.Attr{slog.String("a", "")},
+			},
+			want: colorBlue + "DBG" + colorReset + " " + strings.Repeat("m", 40) + " " + colorCyan + "a=" + colorReset + `""` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := append([]OptionConsole{WithConsoleForce(true), WithConsoleColor(false), WithConsoleLevel(slog.LevelDebug - 4)}, tt.args.opts...)
+			h := NewConsoleHandler(&buf, opts...)
+			if tt.args.with != nil {
+				h = tt.args.with(h)
+			}
+
+			r := slog.NewRecord(tm, tt.args.level, tt.args.msg, 0)
+			r.AddAttrs(tt.args.attrs...)
+			if err := h.Handle(context.Background(), r); err != nil {
+				t.Fatalf("consoleHandler.Handle() error = %v", err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("consoleHandler.Handle() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_consoleLevel(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      slog.Level
+		want      string
+		wantColor string
+	}{
+		{name: "trace", args: LevelTrace, want: "TRC", wantColor: colorFaint},
+		{name: "debug", args: slog.LevelDebug, want: "DBG", wantColor: colorBlue},
+		{name: "info", args: slog.LevelInfo, want: "INF", wantColor: colorGreen},
+		{name: "notice", args: LevelNotice, want: "NTC", wantColor: colorCyan},
+		{name: "warn", args: slog.LevelWarn, want: "WRN", wantColor: colorYellow},
+		{name: "error", args: slog.LevelError, want: "ERR", wantColor: colorRed},
+		{name: "fatal", args: LevelFatal, want: "FTL", wantColor: colorMagenta},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotColor := consoleLevel(tt.args)
+			if got != tt.want {
+				t.Errorf("consoleLevel() = %v, want %v", got, tt.want)
+			}
+			if gotColor != tt.wantColor {
+				t.Errorf("consoleLevel() color = %q, want %q", gotColor, tt.wantColor)
+			}
+		})
+	}
+}
+
+func Test_consoleHandler_Enabled(t *testing.T) {
+	h := NewConsoleHandler(&bytes.Buffer{}, WithConsoleForce(true), WithConsoleLevel(nil))
+	if h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Errorf("consoleHandler.Enabled() = true, want false")
+	}
+	if !h.Enabled(context.Background(), slog.LevelInfo) {
+		t.Errorf("consoleHandler.Enabled() = false, want true")
+	}
+}
+
+func TestNewConsoleHandler_withNewHandler(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(
+		WithMinLevel(slog.LevelDebug),
+		WithHandler(NewConsoleHandler(&buf, WithConsoleForce(true), WithConsoleColor(false), WithConsoleTimeFormat(""))),
+	)
+
+	l.Debug("debug")
+	if got := buf.String(); !strings.Contains(got, "DBG debug") {
+		t.Errorf("output = %q, want the debug record logged by the minimum level of NewHandler", got)
+	}
+}
@@ -4,54 +4,103 @@ import (
 	"context"
 	"errors"
 	"log/slog"
-	"strings"
-
-	"github.com/google/uuid"
+	"slices"
 )
 
 // ErrInvalidMinLevel is returned by [MinLevel] when the given level
-// is not present in the allowed list (debug, info, warn, error).
+// is not present in the allowed list (trace, debug, info, notice, warn, error, fatal) and is not a number.
 var ErrInvalidMinLevel = errors.New("invalid min level")
 
 // LogId returns a new context and log ID after embedding the log identifier in the provided context, using the [ContextLogID] key.
+// It is the same as [LogIdWith] using [GenerateUUIDv7] and [ParseUUID].
 //
 // Behavior:
 //   - Creates a new log id if it does not already exist in the context.
 //   - Try to use propagation to create the new log id.
-func LogId(ctx context.Context, propagation string) (context.Context, uuid.UUID) {
-	u, _ := ctx.Value(ContextLogID).(uuid.UUID)
-	if u != uuid.Nil {
-		return ctx, u
+func LogId(ctx context.Context, propagation string) (context.Context, string) {
+	return LogIdWith(ctx, propagation, GenerateUUIDv7, ParseUUID)
+}
+
+// LogIdWith returns a new context and log ID after embedding the log identifier in the provided context, using the [ContextLogID] key.
+//
+// Behavior:
+//   - Creates a new log id if it does not already exist in the context.
+//   - Try to use propagation to create the new log id, accepting the first valid result of the parsers in order.
+//   - If the propagation is not accepted by any parser, the log id is created by the generator.
+func LogIdWith(ctx context.Context, propagation string, generator LogIDGenerator, parsers ...LogIDParser) (context.Context, string) {
+	if id, _ := ctx.Value(ContextLogID).(string); id != "" {
+		return ctx, id
 	}
 
-	u, err := uuid.Parse(propagation)
-	if err != nil {
-		u, _ = uuid.NewV7()
+	id := ""
+	if propagation != "" {
+		for _, parser := range parsers {
+			if parsed, err := parser(propagation); err == nil {
+				id = parsed
+				break
+			}
+		}
+	}
+	if id == "" {
+		id = generator()
 	}
 
-	return context.WithValue(ctx, ContextLogID, u), u
+	return context.WithValue(ctx, ContextLogID, id), id
+}
+
+// LogBuffer returns a new context after embedding the buffer used by [WithDebugOnError] in the provided context, using the [ContextLogBuffer] key.
+// The records below the minimum log level of the context are buffered until an error is logged or [FlushLogBuffer] is called.
+//
+// Behavior:
+//   - Creates a new buffer if it does not already exist in the context.
+//   - The buffer is only used by handlers created with [WithDebugOnError].
+func LogBuffer(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(ContextLogBuffer).(*logBuffer); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, ContextLogBuffer, &logBuffer{})
 }
 
 // MinLevel returns a new context after embedding the minimum log level in the provided context, using the [ContextMinLevel] key.
-// Allowing lower priority logs at runtime.
+// Overrides the handler minimum level in both directions, allowing lower priority logs or silencing higher priority logs at runtime.
 //
 // The minimum log level can be one of the following options:
+//   - trace: Logs at the trace level [LevelTrace], used for very detailed information such as payloads.
 //   - debug: Logs at the debug level, used for detailed information useful for debugging.
 //   - info: Logs at the informational level, used for general messages about application progress.
+//   - notice: Logs at the notice level [LevelNotice], used for normal but significant events.
 //   - warn: Logs at the warning level, indicating potential issues that do not cause immediate errors.
 //   - error: Logs at the error level, used for serious issues that need attention.
+//   - fatal: Logs at the fatal level [LevelFatal], used for errors that stop the application.
+//
+// A level name can have an offset, like debug+2, and a number is accepted as the level itself, like -8.
 func MinLevel(ctx context.Context, level string) (context.Context, error) {
-	switch strings.ToLower(level) {
-	case "debug":
-		ctx = context.WithValue(ctx, ContextMinLevel, slog.LevelDebug)
-	case "info":
-		ctx = context.WithValue(ctx, ContextMinLevel, slog.LevelInfo)
-	case "warn":
-		ctx = context.WithValue(ctx, ContextMinLevel, slog.LevelWarn)
-	case "error":
-		ctx = context.WithValue(ctx, ContextMinLevel, slog.LevelError)
-	default:
-		return ctx, ErrInvalidMinLevel
+	l, err := parseLevel(level)
+	if err != nil {
+		return ctx, err
+	}
+	return context.WithValue(ctx, ContextMinLevel, l), nil
+}
+
+// AppendCtx returns a new context after embedding the attributes in the provided context, using the [ContextAttrs] key.
+// The attributes are added to every log entry handled with the context, such as tenant ID, user ID or job name.
+//
+// Behavior:
+//   - The attributes are merged with the attributes already embedded in the context.
+//   - Attributes with the same key are deduplicated, keeping the last value.
+//   - The attributes are placed at the top level of the log entry, even after [slog.Logger.WithGroup].
+func AppendCtx(ctx context.Context, attrs ...slog.Attr) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+
+	parent, _ := ctx.Value(ContextAttrs).([]slog.Attr)
+
+	merged := make([]slog.Attr, 0, len(parent)+len(attrs))
+	for _, a := range slices.Concat(parent, attrs) {
+		merged = slices.DeleteFunc(merged, func(m slog.Attr) bool { return m.Key == a.Key })
+		merged = append(merged, a)
 	}
-	return ctx, nil
+
+	return context.WithValue(ctx, ContextAttrs, merged)
 }
@@ -5,8 +5,6 @@ import (
 	"errors"
 	"log/slog"
 	"testing"
-
-	"github.com/google/uuid"
 )
 
 func TestLogId(t *testing.T) {
@@ -17,7 +15,7 @@ func TestLogId(t *testing.T) {
 	tests := []struct {
 		name string
 		args args
-		want uuid.UUID
+		want string
 	}{
 		{
 			name: "empty",
@@ -31,21 +29,28 @@ func TestLogId(t *testing.T) {
 				propagation: "11111111-1111-1111-1111-111111111111",
 				ctx:         context.Background(),
 			},
-			want: uuid.Must(uuid.Parse("11111111-1111-1111-1111-111111111111")),
+			want: "11111111-1111-1111-1111-111111111111",
+		},
+		{
+			name: "invalid propagation",
+			args: args{
+				propagation: "invalid",
+				ctx:         context.Background(),
+			},
 		},
 		{
 			name: "log exists",
 			args: args{
-				ctx: context.WithValue(context.Background(), ContextLogID, uuid.Must(uuid.Parse("11111111-1111-1111-1111-111111111111"))),
+				ctx: context.WithValue(context.Background(), ContextLogID, "11111111-1111-1111-1111-111111111111"),
 			},
-			want: uuid.Must(uuid.Parse("11111111-1111-1111-1111-111111111111")),
+			want: "11111111-1111-1111-1111-111111111111",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx, u := LogId(tt.args.ctx, tt.args.propagation)
 
-			u2, ok := ctx.Value(ContextLogID).(uuid.UUID)
+			u2, ok := ctx.Value(ContextLogID).(string)
 			if !ok {
 				t.Fatal("invalid logger.ContextLogID")
 			}
@@ -53,16 +58,76 @@ func TestLogId(t *testing.T) {
 			if u != u2 {
 				t.Errorf("LogId() = %v, want %v", u, u2)
 			}
-			if u == uuid.Nil {
-				t.Errorf("LogId() = %v, want %v", u, tt.want)
+			if _, err := ParseUUID(u); err != nil {
+				t.Errorf("LogId() = %v, want UUID", u)
 			}
-			if tt.args.propagation != "" && u != tt.want {
+			if tt.want != "" && u != tt.want {
 				t.Errorf("LogId() = %v, want %v", u, tt.want)
 			}
 		})
 	}
 }
 
+func TestLogIdWith(t *testing.T) {
+	type args struct {
+		propagation string
+		parsers     []LogIDParser
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "without parser",
+			args: args{propagation: "abc"},
+			want: "generated",
+		},
+		{
+			name: "first parser accepted",
+			args: args{propagation: "01ARZ3NDEKTSV4RRFFQ69G5FAV", parsers: []LogIDParser{ParseUUID, ParseULID, ParseString}},
+			want: "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		},
+		{
+			name: "passthrough string",
+			args: args{propagation: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", parsers: []LogIDParser{ParseUUID, ParseString}},
+			want: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		},
+		{
+			name: "not accepted",
+			args: args{propagation: "a b", parsers: []LogIDParser{ParseUUID, ParseString}},
+			want: "generated",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got := LogIdWith(context.Background(), tt.args.propagation, func() string { return "generated" }, tt.args.parsers...)
+			if got != tt.want {
+				t.Errorf("LogIdWith() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogIdWith_buffer(t *testing.T) {
+	ctx, _ := LogIdWith(context.Background(), "", func() string { return "generated" })
+	if _, ok := ctx.Value(ContextLogBuffer).(*logBuffer); ok {
+		t.Errorf("LogIdWith() buffer = %v, want %v", ok, false)
+	}
+}
+
+func TestLogBuffer(t *testing.T) {
+	ctx := LogBuffer(context.Background())
+	lb, ok := ctx.Value(ContextLogBuffer).(*logBuffer)
+	if !ok {
+		t.Fatalf("LogBuffer() buffer = %v, want %v", ok, true)
+	}
+
+	if got, _ := LogBuffer(ctx).Value(ContextLogBuffer).(*logBuffer); got != lb {
+		t.Errorf("LogBuffer() = %p, want %p", got, lb)
+	}
+}
+
 func TestMinLevel(t *testing.T) {
 	type args struct {
 		l string
@@ -138,3 +203,59 @@ func TestMinLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestAppendCtx(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		attrs []slog.Attr
+	}
+	tests := []struct {
+		name string
+		args args
+		want []slog.Attr
+	}{
+		{
+			name: "empty",
+			args: args{ctx: context.Background()},
+		},
+		{
+			name: "attrs",
+			args: args{
+				ctx:   context.Background(),
+				attrs: []slog.Attr{slog.String("tenant", "t1"), slog.Int("user", 1)},
+			},
+			want: []slog.Attr{slog.String("tenant", "t1"), slog.Int("user", 1)},
+		},
+		{
+			name: "duplicate attrs",
+			args: args{
+				ctx:   context.Background(),
+				attrs: []slog.Attr{slog.String("tenant", "t1"), slog.String("tenant", "t2")},
+			},
+			want: []slog.Attr{slog.String("tenant", "t2")},
+		},
+		{
+			name: "nested context",
+			args: args{
+				ctx:   AppendCtx(context.Background(), slog.String("tenant", "t1"), slog.String("job", "j1")),
+				attrs: []slog.Attr{slog.String("tenant", "t2"), slog.Int("user", 1)},
+			},
+			want: []slog.Attr{slog.String("job", "j1"), slog.String("tenant", "t2"), slog.Int("user", 1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := AppendCtx(tt.args.ctx, tt.args.attrs...)
+
+			got, _ := ctx.Value(ContextAttrs).([]slog.Attr)
+			if len(got) != len(tt.want) {
+				t.Fatalf("AppendCtx() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("AppendCtx()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
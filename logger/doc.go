@@ -1,8 +1,26 @@
 // Package logger provides a structured logging handler built on top of [slog.Logger].
 //
 // # Key Features:
-//   - Minimum level control for processing a log, This can be dynamically overridden via context with [ContextMinLevel].
+//   - Minimum level control for processing a log, This can be changed at runtime with [LevelController] and dynamically overridden via context with [ContextMinLevel].
+//   - Custom levels [LevelTrace], [LevelNotice] and [LevelFatal] rendered with their names [ReplaceLevelNames], see [Trace] and [Fatal].
+//   - Errors logged with their wrapped and joined errors, their types and the stack trace of where they were created [Err] [Errorf].
 //   - Log identifier is dynamically added to log entry if context [ContextLogID] is set.
-//   - Source code tracing if the log level is less than or equal to the configured maximum level [WithMaxLevelAddSource].
+//   - Attributes are dynamically added to log entry if context [ContextAttrs] is set, see [AppendCtx].
+//   - Sensitive data is masked before the log entry is forwarded [WithRedaction].
+//   - Repeated log entries are sampled with a periodic summary of the dropped entries [WithSampling].
+//   - Log entries below the minimum level are buffered by request and logged only when the request fails [WithDebugOnError].
+//   - OpenTelemetry trace and span identifiers are added to log entry if the context has a valid span [WithTraceKeys].
+//   - Source code tracing if the log level is less than or equal to the configured maximum level [WithMaxLevelAddSource],
+//     with shortened paths, a single file:line attribute and the stack trace of the errors [OptionSource].
+//   - Minimum level overridden by logger name or by package with [LevelRules].
 //   - Delegating the external handler to forward log entries to be processed [WithHandler].
+//   - Forwarding log entries to multiple handlers, each one with its own level and filter [WithSinks].
+//   - Asynchronous delivery to a slow handler with a bounded queue and flush on shutdown [NewAsyncHandler].
+//   - Human-friendly colored console output for local development, falling back to JSON outside a terminal [NewConsoleHandler].
+//   - File writer rotating by size and by time, with compression and retention of the rotated files [NewRotatingFile].
+//   - Configuration from environment variables or from a string, such as a command-line flag [FromEnv] [ParseConfig].
+//   - Attributes and groups added with [slog.Logger.With] and [slog.Logger.WithGroup] keep the handler behavior,
+//     the log identifier and source code attributes are always placed at the top level.
+//
+// The logtest package provides an in-memory handler with query and assertion helpers to test what was logged.
 package logger
@@ -0,0 +1,183 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+)
+
+type (
+	// stackError is an error with the stack trace of where it was created by [Errorf] or [WithStack].
+	stackError struct {
+		err   error
+		stack []uintptr
+	}
+
+	// errorValue is the [slog.LogValuer] of [Err], the error is only walked when the record is logged.
+	errorValue struct {
+		err error
+	}
+
+	errorInfo struct {
+		Message string      `json:"message"`
+		Type    string      `json:"type"`
+		Stack   []string    `json:"stack,omitempty"`
+		Chain   []errorInfo `json:"chain,omitempty"`
+		Errors  []errorInfo `json:"errors,omitempty"`
+	}
+)
+
+const maxStackDepth = 32
+
+// Errorf returns a new error like [fmt.Errorf], capturing the stack trace where it was created to be logged by [Err].
+// The error wrapped with %w is still found by [errors.Is] and [errors.As].
+func Errorf(format string, args ...any) error {
+	return &stackError{err: fmt.Errorf(format, args...), stack: callers()}
+}
+
+// WithStack returns the error with the stack trace where it was called, to be logged by [Err].
+//
+// Behavior:
+//   - A nil error returns nil.
+//   - If the error or an error wrapped by it already has a stack trace, the error is returned unchanged.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	if stackOf(err) != nil {
+		return err
+	}
+	return &stackError{err: err, stack: callers()}
+}
+
+// Err returns an attribute with the key "error" describing the error, instead of only the Error() string of [slog.Any].
+// A nil error returns an empty attribute, ignored by the handlers.
+//
+// The attribute is a group with:
+//   - message: The Error() string.
+//   - type: The type of the error, like *fs.PathError.
+//   - stack: The stack trace, when the error was created by [Errorf] or [WithStack].
+//   - chain: The errors wrapped by it, following [errors.Unwrap], each one with its message and type.
+//   - errors: The errors joined by [errors.Join] or by several %w, each one described like the attribute itself.
+//
+// Example:
+//
+//	slog.Error("order not saved", logger.Err(err))
+func Err(err error) slog.Attr {
+	if err == nil {
+		return slog.Attr{}
+	}
+	return slog.Any("error", errorValue{err: err})
+}
+
+func (se *stackError) Error() string {
+	return se.err.Error()
+}
+
+func (se *stackError) Unwrap() error {
+	return se.err
+}
+
+// callers returns the stack trace of the caller of the exported function.
+func callers() []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(3, pcs) // skip [runtime.Callers, callers, Errorf or WithStack]
+	return pcs[:n]
+}
+
+// skipStack returns the first error of the chain that is not a [stackError].
+func skipStack(err error) error {
+	for {
+		se, ok := err.(*stackError)
+		if !ok {
+			return err
+		}
+		err = se.err
+	}
+}
+
+// stackOf returns the stack trace of the innermost [stackError] of the chain, the closest to where the error was created.
+// The errors joined are not walked, each one has its own stack trace.
+func stackOf(err error) []uintptr {
+	var stack []uintptr
+	for err != nil {
+		if se, ok := err.(*stackError); ok {
+			stack = se.stack
+		}
+		err = errors.Unwrap(err)
+	}
+	return stack
+}
+
+func formatStack(stack []uintptr) []string {
+	if len(stack) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0, len(stack))
+	frames := runtime.CallersFrames(stack)
+	for {
+		f, more := frames.Next()
+		lines = append(lines, fmt.Sprintf("%s %s:%d", f.Function, f.File, f.Line))
+		if !more {
+			return lines
+		}
+	}
+}
+
+func newErrorInfo(err error) errorInfo {
+	info := errorInfo{Stack: formatStack(stackOf(err))}
+
+	err = skipStack(err)
+	info.Message = err.Error()
+	info.Type = fmt.Sprintf("%T", err)
+
+	// joined is the index in the chain of the error joining other errors, -1 is the error itself.
+	joined := -1
+	for {
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			err = skipStack(u.Unwrap())
+			if err == nil {
+				return info
+			}
+			info.Chain = append(info.Chain, errorInfo{Message: err.Error(), Type: fmt.Sprintf("%T", err)})
+			joined++
+		case interface{ Unwrap() []error }:
+			var members []errorInfo
+			for _, e := range u.Unwrap() {
+				if e != nil {
+					members = append(members, newErrorInfo(e))
+				}
+			}
+			if joined < 0 {
+				info.Errors = members
+			} else {
+				info.Chain[joined].Errors = members
+			}
+			return info
+		default:
+			return info
+		}
+	}
+}
+
+func (ev errorValue) LogValue() slog.Value {
+	info := newErrorInfo(ev.err)
+
+	attrs := []slog.Attr{
+		slog.String("message", info.Message),
+		slog.String("type", info.Type),
+	}
+	if len(info.Stack) > 0 {
+		attrs = append(attrs, slog.Any("stack", info.Stack))
+	}
+	if len(info.Chain) > 0 {
+		attrs = append(attrs, slog.Any("chain", info.Chain))
+	}
+	if len(info.Errors) > 0 {
+		attrs = append(attrs, slog.Any("errors", info.Errors))
+	}
+	return slog.GroupValue(attrs...)
+}
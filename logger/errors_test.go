@@ -0,0 +1,149 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestErrorf(t *testing.T) {
+	err := Errorf("loading %s: %w", "config", fs.ErrNotExist)
+
+	if got := err.Error(); got != "loading config: file does not exist" {
+		t.Errorf("Errorf() = %v, want %v", got, "loading config: file does not exist")
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("errors.Is() = false, want true")
+	}
+
+	stack := formatStack(stackOf(err))
+	if len(stack) == 0 || !strings.Contains(stack[0], "TestErrorf") {
+		t.Errorf("Errorf() stack = %v, want TestErrorf first", stack)
+	}
+}
+
+func TestWithStack(t *testing.T) {
+	withStack := Errorf("fail")
+
+	tests := []struct {
+		name      string
+		args      error
+		wantSame  bool
+		wantStack bool
+	}{
+		{name: "nil", args: nil, wantSame: true},
+		{name: "without stack", args: fs.ErrNotExist, wantStack: true},
+		{name: "with stack", args: withStack, wantSame: true, wantStack: true},
+		{name: "wrapped with stack", args: fmt.Errorf("wrap: %w", withStack), wantSame: true, wantStack: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WithStack(tt.args)
+			if (got == tt.args) != tt.wantSame {
+				t.Errorf("WithStack() same = %v, want %v", got == tt.args, tt.wantSame)
+			}
+			if !errors.Is(got, tt.args) {
+				t.Errorf("errors.Is() = false, want true")
+			}
+			if (stackOf(got) != nil) != tt.wantStack {
+				t.Errorf("WithStack() stack = %v, want %v", stackOf(got) != nil, tt.wantStack)
+			}
+		})
+	}
+}
+
+func TestErr(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      error
+		want      string
+		wantStack bool
+	}{
+		{
+			name: "nil",
+			args: nil,
+			want: `{"msg":"failed"}`,
+		},
+		{
+			name: "simple",
+			args: fs.ErrNotExist,
+			want: `{"msg":"failed","error":{"message":"file does not exist","type":"*errors.errorString"}}`,
+		},
+		{
+			name: "chain",
+			args: fmt.Errorf("loading: %w", &fs.PathError{Op: "open", Path: "app.yaml", Err: fs.ErrNotExist}),
+			want: `{"msg":"failed","error":{"message":"loading: open app.yaml: file does not exist","type":"*fmt.wrapError","chain":[` +
+				`{"message":"open app.yaml: file does not exist","type":"*fs.PathError"},` +
+				`{"message":"file does not exist","type":"*errors.errorString"}]}}`,
+		},
+		{
+			name: "join",
+			args: errors.Join(fs.ErrNotExist, nil, fmt.Errorf("closing: %w", fs.ErrClosed)),
+			want: `{"msg":"failed","error":{"message":"file does not exist\nclosing: file already closed","type":"*errors.joinError","errors":[` +
+				`{"message":"file does not exist","type":"*errors.errorString"},` +
+				`{"message":"closing: file already closed","type":"*fmt.wrapError","chain":[{"message":"file already closed","type":"*errors.errorString"}]}]}}`,
+		},
+		{
+			name: "join in chain",
+			args: fmt.Errorf("saving: %w", errors.Join(fs.ErrExist)),
+			want: `{"msg":"failed","error":{"message":"saving: file already exists","type":"*fmt.wrapError","chain":[` +
+				`{"message":"file already exists","type":"*errors.joinError","errors":[{"message":"file already exists","type":"*errors.errorString"}]}]}}`,
+		},
+		{
+			name:      "with stack",
+			args:      fmt.Errorf("saving: %w", Errorf("fail")),
+			want:      `{"msg":"failed","error":{"message":"saving: fail","type":"*fmt.wrapError","chain":[{"message":"fail","type":"*errors.errorString"}]}}`,
+			wantStack: true,
+		},
+		{
+			name:      "wrapped unwrap nil",
+			args:      WithStack(Errorf("%w", nil)),
+			want:      `{"msg":"failed","error":{"message":"%!w(<nil>)","type":"*fmt.wrapError"}}`,
+			wantStack: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			var gotStack bool
+			l := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					switch {
+					case len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey):
+						return slog.Attr{}
+					case len(groups) == 1 && a.Key == "stack":
+						stack, _ := a.Value.Any().([]string)
+						gotStack = len(stack) > 0
+						return slog.Attr{}
+					}
+					return a
+				},
+			}))
+
+			l.Error("failed", Err(tt.args))
+
+			if gotStack != tt.wantStack {
+				t.Errorf("Err() stack = %v, want %v", gotStack, tt.wantStack)
+			}
+			if got := strings.TrimSpace(buf.String()); got != tt.want {
+				t.Errorf("Err() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErr_context(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(WithHandler(slog.NewTextHandler(&buf, nil)), WithMaxLevelAddSource(levelAll))
+
+	l.ErrorContext(context.Background(), "failed", Err(fs.ErrNotExist))
+
+	if got := buf.String(); !strings.Contains(got, `error.message="file does not exist" error.type=*errors.errorString`) {
+		t.Errorf("Err() = %v", got)
+	}
+}
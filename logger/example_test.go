@@ -2,8 +2,14 @@ package logger_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"log/slog"
+	"os"
+	"time"
 
+	"github.com/telmoandrade/go-library/httpserver"
 	"github.com/telmoandrade/go-library/logger"
 	"go.opentelemetry.io/contrib/bridges/otelslog"
 )
@@ -36,7 +42,7 @@ func ExampleLogId() {
 	slog.SetDefault(logger.NewLogger())
 
 	ctx, u := logger.LogId(context.Background(), "")
-	slog.DebugContext(ctx, "message debug", slog.String("id", u.String()))
+	slog.DebugContext(ctx, "message debug", slog.String("id", u))
 
 	// Output:
 }
@@ -49,3 +55,253 @@ func ExampleMinLevel() {
 
 	// Output:
 }
+
+func ExampleNewLevelController() {
+	lc := logger.NewLevelController(slog.LevelInfo)
+
+	slog.SetDefault(logger.NewLogger(
+		logger.WithLevelController(lc),
+		logger.WithHandler(otelslog.NewHandler("")),
+	))
+
+	mux := httpserver.NewServeMux()
+	mux.Get("/logger/level", lc.ServeHTTP)
+	mux.Put("/logger/level", lc.ServeHTTP)
+
+	lc.SetLevelTTL(slog.LevelDebug, 5*time.Minute)
+	fmt.Println(lc.Level())
+	// Output: DEBUG
+}
+
+func ExampleParseLevelRules() {
+	rules, err := logger.ParseLevelRules("billing=debug,go.opentelemetry.io=warn")
+	if err != nil {
+		return
+	}
+
+	slog.SetDefault(logger.NewLogger(
+		logger.WithLevelRules(rules),
+		logger.WithHandler(otelslog.NewHandler("")),
+	))
+
+	l := logger.Named("billing")
+	l.Debug("message debug")
+
+	fmt.Println(rules)
+	// Output: billing=debug,go.opentelemetry.io=warn
+}
+
+func ExampleAppendCtx() {
+	slog.SetDefault(logger.NewLogger())
+
+	ctx := logger.AppendCtx(context.Background(), slog.String("tenant", "t1"))
+	ctx = logger.AppendCtx(ctx, slog.Int("user", 1))
+	slog.DebugContext(ctx, "message debug")
+
+	// Output:
+}
+
+func ExampleLogIdWith() {
+	ctx, id := logger.LogIdWith(context.Background(), "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		logger.GenerateULID,
+		logger.ParseUUID,
+		logger.ParseULID,
+	)
+
+	slog.DebugContext(ctx, "message debug")
+	fmt.Println(id)
+	// Output: 01ARZ3NDEKTSV4RRFFQ69G5FAV
+}
+
+func ExampleWithRedaction() {
+	l := logger.NewLogger(
+		logger.WithRedaction(
+			logger.WithRedactKeys("ssn"),
+			logger.WithRedactMode(logger.RedactPartial, nil),
+		),
+		logger.WithHandler(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		})),
+	)
+
+	l.Info("payment", slog.String("card", "4111 1111 1111 1111"), slog.String("ssn", "123-45-6789"))
+	// Output: level=INFO msg=payment card=****1111 ssn=****6789
+}
+
+func ExampleWithSampling() {
+	l := logger.NewLogger(
+		logger.WithSampling(
+			logger.WithSampleRate(2, 0),
+			logger.WithSampleExemptLevel(slog.LevelError),
+			logger.WithSampleSummary(0),
+		),
+		logger.WithHandler(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		})),
+	)
+
+	for i := 0; i < 3; i++ {
+		l.Info("retrying", slog.Int("attempt", i))
+	}
+	l.Error("failed")
+	// Output:
+	// level=INFO msg=retrying attempt=0
+	// level=INFO msg=retrying attempt=1
+	// level=ERROR msg=failed
+}
+
+func ExampleWithSinks() {
+	replaceAttr := func(groups []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}
+
+	l := logger.NewLogger(
+		logger.WithMinLevel(slog.LevelDebug),
+		logger.WithMaxLevelAddSource(slog.LevelDebug-1),
+		logger.WithSinks(
+			logger.NewSink(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{ReplaceAttr: replaceAttr}), logger.WithSinkLevel(slog.LevelInfo)),
+			logger.NewSink(otelslog.NewHandler(""), logger.WithSinkLevel(slog.LevelDebug)),
+			logger.NewSink(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{ReplaceAttr: replaceAttr}), logger.WithSinkLevel(slog.LevelError)),
+		),
+	)
+
+	l.Debug("message debug")
+	l.Error("message error")
+	// Output:
+	// {"level":"ERROR","msg":"message error"}
+	// level=ERROR msg="message error"
+}
+
+func ExampleWithDebugOnError() {
+	l := logger.NewLogger(
+		logger.WithDebugOnError(100),
+		logger.WithMaxLevelAddSource(slog.LevelDebug-1),
+		logger.WithHandler(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		})),
+	)
+
+	ctx, _ := logger.LogIdWith(context.Background(), "", func() string { return "1" })
+	ctx = logger.LogBuffer(ctx)
+	l.DebugContext(ctx, "loading order")
+	l.ErrorContext(ctx, "order not found")
+	// Output:
+	// level=DEBUG msg="loading order" log.id=1
+	// level=ERROR msg="order not found" log.id=1
+}
+
+func ExampleNewRotatingFile() {
+	rf, err := logger.NewRotatingFile("/var/log/app/app.log",
+		logger.WithMaxSize(100<<20),
+		logger.WithRotateInterval(24*time.Hour),
+		logger.WithCompress(true),
+		logger.WithMaxBackups(10),
+		logger.WithMaxAge(30*24*time.Hour),
+	)
+	if err != nil {
+		return
+	}
+	defer rf.Close(context.Background())
+
+	slog.SetDefault(logger.NewLogger(
+		logger.WithHandler(slog.NewJSONHandler(rf, nil)),
+	))
+}
+
+func ExampleNewConsoleHandler() {
+	l := logger.NewLogger(
+		logger.WithMaxLevelAddSource(slog.LevelDebug-1),
+		logger.WithHandler(logger.NewConsoleHandler(os.Stdout,
+			logger.WithConsoleForce(true),
+			logger.WithConsoleColor(false),
+			logger.WithConsoleTimeFormat(""),
+		)),
+	)
+
+	l.Info("HTTP Response 200",
+		slog.Group("request", slog.String("method", "GET"), slog.String("path", "/")),
+		slog.Group("response", slog.Int("status", 200)),
+	)
+	// Output:
+	// INF HTTP Response 200
+	//   request: method=GET path=/
+	//   response: status=200
+}
+
+func ExampleFromEnv() {
+	// LOG_LEVEL=debug LOG_FORMAT=json LOG_RULES=billing=warn LOG_SAMPLING=100/100/1s
+	h, closeLog, err := logger.FromEnv(logger.WithName("app"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer closeLog(context.Background())
+
+	slog.SetDefault(slog.New(h))
+}
+
+func ExampleParseConfig() {
+	_, _, err := logger.ParseConfig("level=verbose format=json")
+	fmt.Println(err)
+	// Output:
+	// level: invalid min level: "verbose" must be trace, debug, info, notice, warn, error, fatal or a number
+}
+
+func ExampleTrace() {
+	slog.SetDefault(logger.NewLogger(
+		logger.WithMinLevel(logger.LevelTrace),
+		logger.WithMaxLevelAddSource(logger.LevelTrace-1),
+		logger.WithHandler(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return logger.ReplaceLevelNames(groups, a)
+			},
+		})),
+	))
+
+	logger.Trace(context.Background(), "request payload", "size", 128)
+	slog.Log(context.Background(), logger.LevelNotice, "configuration reloaded")
+	// Output:
+	// level=TRACE msg="request payload" size=128
+	// level=NOTICE msg="configuration reloaded"
+}
+
+func ExampleErr() {
+	l := logger.NewLogger(
+		logger.WithMaxLevelAddSource(slog.LevelDebug-1),
+		logger.WithHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		})),
+	)
+
+	err := fmt.Errorf("loading config: %w", errors.Join(fs.ErrNotExist, fs.ErrPermission))
+	l.Error("startup failed", logger.Err(err))
+	// Output:
+	// {"level":"ERROR","msg":"startup failed","error":{"message":"loading config: file does not exist\npermission denied","type":"*fmt.wrapError","chain":[{"message":"file does not exist\npermission denied","type":"*errors.joinError","errors":[{"message":"file does not exist","type":"*errors.errorString"},{"message":"permission denied","type":"*errors.errorString"}]}]}}
+}
@@ -2,18 +2,43 @@ package logger
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
 	"runtime"
+	"slices"
 
-	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type (
 	loggerHandler struct {
 		handler           slog.Handler
-		minLevel          slog.Level
+		groups            []groupOrAttrs
+		name              string
+		level             *LevelController
+		minLevel          *slog.Level
+		rules             *LevelRules
 		maxLevelAddSource slog.Level
+		source            sourceOptions
+		traceKeys         traceKeys
+		logIDFromTrace    bool
+		redactor          *redactor
+		sampler           *sampler
+		bufferSize        int
+	}
+
+	traceKeys struct {
+		traceID    string
+		spanID     string
+		traceFlags string
+	}
+
+	// groupOrAttrs holds a group or attributes added after the first group is opened,
+	// they are applied when handling the record so the handler attributes stay at the top level.
+	groupOrAttrs struct {
+		group string
+		attrs []slog.Attr
 	}
 
 	contextKey struct {
@@ -25,12 +50,18 @@ type (
 )
 
 var (
-	// ContextLogID is used to record the log identifier in the context.
-	// The identifier helps to track and distinguish different logs throughout the system.
+	// ContextLogID is used to record the log identifier in the context, the value is a string.
+	// The identifier helps to track and distinguish different logs throughout the system, see [LogIdWith].
 	ContextLogID = &contextKey{"logID"}
 	// ContextMinLevel is used to set the minimum log level in the context.
-	// The minimum log level defines the threshold for which logs are processed, allowing lower priority logs at runtime.
+	// The minimum log level defines the threshold for which logs are processed, overriding the handler minimum level and [LevelRules].
+	// It can either allow lower priority logs or silence higher priority logs at runtime.
 	ContextMinLevel = &contextKey{"minLevel"}
+	// ContextAttrs is used to record in the context the attributes added to every log entry, see [AppendCtx].
+	ContextAttrs = &contextKey{"attrs"}
+	// ContextLogBuffer is used to record in the context the buffer of records below the minimum log level, see [WithDebugOnError].
+	// It is added by [LogBuffer].
+	ContextLogBuffer = &contextKey{"logBuffer"}
 
 	_ slog.Handler = &loggerHandler{}
 )
@@ -46,23 +77,53 @@ func NewLogger(opts ...Option) *slog.Logger {
 // A variadic set of [Option] used to configure the behavior of the handler.
 //
 // Behavior:
-//   - Minimum level control for processing a log, This can be dynamically overridden via context with [ContextMinLevel].
+//   - Minimum level control for processing a log, This can be changed at runtime with [LevelController] and dynamically overridden via context with [ContextMinLevel].
 //   - Log identifier is dynamically added to log entry if context [ContextLogID] is set.
-//   - Source code tracing if the log level is less than or equal to the configured maximum level [WithMaxLevelAddSource].
+//   - Attributes are dynamically added to log entry if context [ContextAttrs] is set, see [AppendCtx].
+//   - Sensitive data is masked before the log entry is forwarded [WithRedaction].
+//   - Repeated log entries are sampled with a periodic summary of the dropped entries [WithSampling].
+//   - Log entries below the minimum level are buffered by request and logged only when the request fails [WithDebugOnError].
+//   - OpenTelemetry trace and span identifiers are added to log entry if the context has a valid span [WithTraceKeys].
+//   - Source code tracing if the log level is less than or equal to the configured maximum level [WithMaxLevelAddSource],
+//     with shortened paths, a single file:line attribute and the stack trace of the errors [OptionSource].
+//   - Minimum level overridden by logger name or by package with [LevelRules].
 //   - Delegating the external handler to forward log entries to be processed [WithHandler].
+//   - Forwarding log entries to multiple handlers, each one with its own level and filter [WithSinks].
+//   - Attributes and groups added with [slog.Logger.With] and [slog.Logger.WithGroup] keep the handler behavior,
+//     the log identifier and source code attributes are always placed at the top level.
+//
+// Important Note:
+//   - The handler has the methods Flush(ctx context.Context) error and Close(ctx context.Context) error,
+//     logging the pending summary of [WithSampling] and then flushing or closing the handler defined by [WithHandler],
+//     so it can be closed in the shutdown process instead of the downstream handler.
 func NewHandler(opts ...Option) slog.Handler {
 	lh := &loggerHandler{
-		handler:           slog.NewTextHandler(os.Stdout, nil),
-		minLevel:          slog.LevelInfo,
+		handler:           slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{ReplaceAttr: ReplaceLevelNames}),
+		level:             NewLevelController(slog.LevelInfo),
 		maxLevelAddSource: slog.LevelDebug,
+		traceKeys: traceKeys{
+			traceID:    "trace_id",
+			spanID:     "span_id",
+			traceFlags: "trace_flags",
+		},
 	}
 
-	slog.Default()
-
 	for _, opt := range opts {
 		opt(lh)
 	}
 
+	// The level and the rules are set after the options, so they are not lost when the controller is defined after them.
+	if lh.minLevel != nil {
+		lh.level.SetLevel(*lh.minLevel)
+	}
+	if lh.rules != nil {
+		lh.level.SetRules(*lh.rules)
+	}
+
+	if lh.sampler != nil {
+		lh.sampler.handler = lh.handler
+	}
+
 	return lh
 }
 
@@ -71,17 +132,74 @@ func NewHandler(opts ...Option) slog.Handler {
 //
 // Default:
 //   - The default minimum log level is [slog.LevelInfo], meaning only informational messages and above (warnings, errors, etc.) will be logged.
+//
+// Important Note:
+//   - The level is set on the handler [LevelController] after applying every option,
+//     when used with [WithLevelController] it changes the shared controller, regardless of the order of the options.
 func WithMinLevel(level slog.Level) Option {
 	return func(lh *loggerHandler) {
-		lh.minLevel = level
+		lh.minLevel = &level
+	}
+}
+
+// WithLevelController is an [Option] that defines the [LevelController] used to change the minimum log level at runtime.
+// The same controller can be shared by several handlers.
+//
+// Default:
+//   - If no controller is provided, each handler creates its own controller with the level defined by [WithMinLevel].
+func WithLevelController(lc *LevelController) Option {
+	return func(lh *loggerHandler) {
+		if lc != nil {
+			lh.level = lc
+		}
 	}
 }
 
+// WithLevelRules is an [Option] that defines the [LevelRules] to override the minimum log level by logger name or by package.
+// The rules are set on the handler [LevelController] and can be changed at runtime with [LevelController.SetRules].
+//
+// Important Note:
+//   - The rules are set after applying every option, regardless of the order of [WithLevelController].
+//   - Rules lower than the minimum log level require resolving the caller function for records below the minimum level,
+//     the records are discarded after when no rule allows them.
+func WithLevelRules(rules LevelRules) Option {
+	return func(lh *loggerHandler) {
+		lh.rules = &rules
+	}
+}
+
+// WithName is an [Option] that defines the logger name used to match [LevelRules].
+// The name is added to the log entry in the logger attribute.
+func WithName(name string) Option {
+	return func(lh *loggerHandler) {
+		lh.name = name
+	}
+}
+
+// Named returns a new [slog.Logger] from [slog.Default] with the logger name used to match [LevelRules].
+// The name is added to the log entry in the logger attribute.
+//
+// Behavior:
+//   - If the default handler was not created with [NewHandler], the name is only added as an attribute.
+func Named(name string) *slog.Logger {
+	l := slog.Default()
+	if lh, ok := l.Handler().(*loggerHandler); ok {
+		c := lh.clone()
+		c.name = name
+		return slog.New(c)
+	}
+	return l.With(slog.String("logger", name))
+}
+
 // WithHandler is an [Option] that defines an external [slog.Handler] to which the processed log entries will be forwarded.
 // This allows for chaining log handlers or customizing how logs are written or formatted.
 //
 // Default:
 //   - If no custom handler is provided, the default handler is [slog.NewTextHandler], which outputs plain-text logs to the console [os.Stdout].
+//
+// Important Note:
+//   - To forward log entries to more than one handler, use [WithSinks].
+//   - For local development, [NewConsoleHandler] writes human-friendly log entries.
 func WithHandler(handler slog.Handler) Option {
 	return func(lh *loggerHandler) {
 		if handler != nil {
@@ -100,50 +218,254 @@ func WithHandler(handler slog.Handler) Option {
 //   - source.function: The function where the log was generated.
 //   - source.file: The file where the log was generated.
 //   - source.line: The line number of the log statement.
-func WithMaxLevelAddSource(level slog.Level) Option {
+//
+// A variadic set of [OptionSource] used to configure the source code information:
+//   - [WithSourceTrimPrefix] and [WithSourceTrimGOPATH] shorten source.file.
+//   - [WithSourceFileLine] adds a single attribute source=file:line.
+//   - [WithSourceStack] adds the stack trace to the log entries at or above a level.
+//
+// Important Note:
+//   - Records without a caller, with a zero PC, never have source code information.
+func WithMaxLevelAddSource(level slog.Level, opts ...OptionSource) Option {
 	return func(lh *loggerHandler) {
 		lh.maxLevelAddSource = level
+		lh.source = sourceOptions{}
+		for _, opt := range opts {
+			opt(&lh.source)
+		}
+	}
+}
+
+// WithTraceKeys is an [Option] that defines the attribute keys of the OpenTelemetry span context added to the log entry.
+// An empty key omits the attribute.
+//
+// Default:
+//   - The default keys are trace_id, span_id and trace_flags.
+func WithTraceKeys(traceID, spanID, traceFlags string) Option {
+	return func(lh *loggerHandler) {
+		lh.traceKeys = traceKeys{
+			traceID:    traceID,
+			spanID:     spanID,
+			traceFlags: traceFlags,
+		}
+	}
+}
+
+// WithLogIDFromTrace is an [Option] that uses the OpenTelemetry trace ID as the log identifier,
+// so the log.id attribute and the trace ID are a single correlation key.
+//
+// Default Behavior:
+//   - If the context has no valid span, the log identifier from the context [ContextLogID] is used.
+func WithLogIDFromTrace(enabled bool) Option {
+	return func(lh *loggerHandler) {
+		lh.logIDFromTrace = enabled
 	}
 }
 
 func (lh *loggerHandler) Enabled(ctx context.Context, l slog.Level) bool {
-	minLevel := lh.minLevel
+	if lh.buffer(ctx) != nil {
+		return true
+	}
 
-	if l < minLevel {
-		if l2, ok := ctx.Value(ContextMinLevel).(slog.Level); ok {
-			minLevel = l2
-		}
+	if l2, ok := ctx.Value(ContextMinLevel).(slog.Level); ok {
+		return l >= l2
+	}
+
+	minLevel := lh.level.Level()
+	if rules := lh.level.Rules(); !rules.empty() {
+		minLevel = min(minLevel, rules.minLevel)
 	}
 
 	return l >= minLevel
 }
 
+// enabledRecord reports whether the record is at or above the effective minimum level,
+// resolved from the context, the rule matching the logger name or the caller package, or the handler.
+func (lh *loggerHandler) enabledRecord(ctx context.Context, r slog.Record, f runtime.Frame, rules bool) bool {
+	if l, ok := ctx.Value(ContextMinLevel).(slog.Level); ok {
+		return r.Level >= l
+	}
+
+	minLevel := lh.level.Level()
+	if rules {
+		if l, ok := lh.level.Rules().match(lh.name, functionPackage(f.Function)); ok {
+			minLevel = l
+		}
+	}
+
+	return r.Level >= minLevel
+}
+
+// buffer returns the buffer of the context if the handler was created with [WithDebugOnError].
+func (lh *loggerHandler) buffer(ctx context.Context) *logBuffer {
+	if lh.bufferSize == 0 {
+		return nil
+	}
+	lb, _ := ctx.Value(ContextLogBuffer).(*logBuffer)
+	return lb
+}
+
+func (lh *loggerHandler) traceAttrs(sc trace.SpanContext) []slog.Attr {
+	attrs := make([]slog.Attr, 0, 3)
+	if lh.traceKeys.traceID != "" {
+		attrs = append(attrs, slog.String(lh.traceKeys.traceID, sc.TraceID().String()))
+	}
+	if lh.traceKeys.spanID != "" {
+		attrs = append(attrs, slog.String(lh.traceKeys.spanID, sc.SpanID().String()))
+	}
+	if lh.traceKeys.traceFlags != "" {
+		attrs = append(attrs, slog.String(lh.traceKeys.traceFlags, sc.TraceFlags().String()))
+	}
+	return attrs
+}
+
+func callerFrame(pc uintptr) runtime.Frame {
+	fs := runtime.CallersFrames([]uintptr{pc})
+	f, _ := fs.Next()
+	return f
+}
+
 func (lh *loggerHandler) Handle(ctx context.Context, r slog.Record) error {
-	if u, ok := ctx.Value(ContextLogID).(uuid.UUID); ok {
-		if u != uuid.Nil {
-			r.AddAttrs(slog.Group("log",
-				slog.String("id", u.String()),
-			))
+	addSource := r.PC != 0 && r.Level <= lh.maxLevelAddSource
+	rules := !lh.level.Rules().empty()
+	lb := lh.buffer(ctx)
+
+	var f runtime.Frame
+	if addSource || rules || lb != nil {
+		f = callerFrame(r.PC)
+	}
+
+	if !lh.enabledRecord(ctx, r, f, rules) {
+		if lb == nil {
+			return nil
+		}
+		buffered := lb.add(lh.bufferSize, func() bufferedRecord {
+			return bufferedRecord{ctx: ctx, handler: lh.handler, record: lh.prepare(ctx, r, f, addSource).Clone()}
+		})
+		if buffered {
+			return nil
 		}
 	}
 
-	if r.Level <= lh.maxLevelAddSource {
-		fs := runtime.CallersFrames([]uintptr{r.PC})
-		f, _ := fs.Next()
-		r.AddAttrs(slog.Group("source",
-			slog.String("function", f.Function),
-			slog.String("file", f.File),
-			slog.Int("line", f.Line),
-		))
+	if lh.sampler != nil && !lh.sampler.allow(r) {
+		return nil
+	}
+
+	r = lh.prepare(ctx, r, f, addSource)
+
+	if lb != nil && r.Level >= slog.LevelError {
+		return errors.Join(lb.flush(), lh.handler.Handle(ctx, r))
 	}
 
 	return lh.handler.Handle(ctx, r)
 }
 
+// prepare returns a new record with the handler attributes, ready to be forwarded to the handler.
+func (lh *loggerHandler) prepare(ctx context.Context, r slog.Record, f runtime.Frame, addSource bool) slog.Record {
+	if lh.redactor != nil {
+		r = lh.redactor.record(r)
+	}
+
+	r = lh.regroup(r)
+
+	if attrs, ok := ctx.Value(ContextAttrs).([]slog.Attr); ok {
+		if lh.redactor != nil {
+			attrs = lh.redactor.attrs(attrs)
+		}
+		r.AddAttrs(attrs...)
+	}
+
+	if lh.name != "" {
+		r.AddAttrs(slog.String("logger", lh.name))
+	}
+
+	logID, _ := ctx.Value(ContextLogID).(string)
+
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		if lh.logIDFromTrace {
+			logID = sc.TraceID().String()
+		}
+		r.AddAttrs(lh.traceAttrs(sc)...)
+	}
+
+	if logID != "" {
+		r.AddAttrs(slog.Group("log",
+			slog.String("id", logID),
+		))
+	}
+
+	if addSource {
+		r.AddAttrs(lh.source.attr(f))
+	}
+
+	if lh.source.addStack(r) {
+		r.AddAttrs(slog.Any("stack", sourceStack(r.PC)))
+	}
+
+	return r
+}
+
+// regroup returns a new record with the attributes nested in the open groups,
+// allowing new attributes to be added at the top level of the record.
+func (lh *loggerHandler) regroup(r slog.Record) slog.Record {
+	if len(lh.groups) == 0 {
+		return r
+	}
+
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+
+	nr := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	nr.AddAttrs(nestAttrs(lh.groups, attrs)...)
+	return nr
+}
+
+// nestAttrs returns the attributes nested in the groups, preceded by the attributes added to each group.
+func nestAttrs(groups []groupOrAttrs, attrs []slog.Attr) []slog.Attr {
+	for i := len(groups) - 1; i >= 0; i-- {
+		goa := groups[i]
+		if goa.group != "" {
+			attrs = []slog.Attr{{Key: goa.group, Value: slog.GroupValue(attrs...)}}
+		} else {
+			attrs = append(slices.Clip(goa.attrs), attrs...)
+		}
+	}
+	return attrs
+}
+
+func (lh *loggerHandler) clone() *loggerHandler {
+	c := *lh
+	c.groups = slices.Clip(lh.groups)
+	return &c
+}
+
 func (lh *loggerHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return lh.handler.WithAttrs(attrs)
+	if len(attrs) == 0 {
+		return lh
+	}
+
+	if lh.redactor != nil {
+		attrs = lh.redactor.attrs(attrs)
+	}
+
+	c := lh.clone()
+	if len(c.groups) == 0 {
+		c.handler = c.handler.WithAttrs(attrs)
+	} else {
+		c.groups = append(c.groups, groupOrAttrs{attrs: attrs})
+	}
+	return c
 }
 
 func (lh *loggerHandler) WithGroup(name string) slog.Handler {
-	return lh.handler.WithGroup(name)
+	if name == "" {
+		return lh
+	}
+
+	c := lh.clone()
+	c.groups = append(c.groups, groupOrAttrs{group: name})
+	return c
 }
@@ -1,12 +1,15 @@
 package logger
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"log/slog"
 	"os"
+	"reflect"
 	"testing"
 
-	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/mock/gomock"
 )
 
@@ -86,9 +89,8 @@ func TestWithMinLevel(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			lh := &loggerHandler{}
-			WithMinLevel(tt.args.l)(lh)
-			if got := lh.minLevel; got != tt.want {
+			lh := NewHandler(WithMinLevel(tt.args.l)).(*loggerHandler)
+			if got := lh.level.Level(); got != tt.want {
 				t.Errorf("WithMinLevel() = %v, want %v", got, tt.want)
 			}
 		})
@@ -131,6 +133,115 @@ func TestWithHandler(t *testing.T) {
 	}
 }
 
+func TestWithLevelController(t *testing.T) {
+	lc := NewLevelController(slog.LevelWarn)
+	tests := []struct {
+		name string
+		args *LevelController
+		want *LevelController
+	}{
+		{name: "nil controller", args: nil},
+		{name: "controller", args: lc, want: lc},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := &LevelController{}
+			lh := &loggerHandler{level: current}
+			WithLevelController(tt.args)(lh)
+
+			want := tt.want
+			if want == nil {
+				want = current
+			}
+			if lh.level != want {
+				t.Errorf("WithLevelController() = %p, want %p", lh.level, want)
+			}
+		})
+	}
+}
+
+func TestWithLevelRules(t *testing.T) {
+	rules, _ := ParseLevelRules("billing=debug")
+
+	lh := NewHandler(WithLevelRules(rules)).(*loggerHandler)
+	if got := lh.level.Rules().String(); got != rules.String() {
+		t.Errorf("WithLevelRules() = %v, want %v", got, rules.String())
+	}
+}
+
+func TestNewHandler_levelOptionsOrder(t *testing.T) {
+	rules, _ := ParseLevelRules("billing=debug")
+
+	tests := []struct {
+		name string
+		opts func(lc *LevelController) []Option
+	}{
+		{
+			name: "controller last",
+			opts: func(lc *LevelController) []Option {
+				return []Option{WithMinLevel(slog.LevelWarn), WithLevelRules(rules), WithLevelController(lc)}
+			},
+		},
+		{
+			name: "controller first",
+			opts: func(lc *LevelController) []Option {
+				return []Option{WithLevelController(lc), WithMinLevel(slog.LevelWarn), WithLevelRules(rules)}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := NewLevelController(slog.LevelInfo)
+			lh := NewHandler(tt.opts(lc)...).(*loggerHandler)
+
+			if lh.level != lc {
+				t.Errorf("NewHandler() controller = %p, want %p", lh.level, lc)
+			}
+			if got := lc.Level(); got != slog.LevelWarn {
+				t.Errorf("LevelController.Level() = %v, want %v", got, slog.LevelWarn)
+			}
+			if got := lc.Rules().String(); got != rules.String() {
+				t.Errorf("LevelController.Rules() = %v, want %v", got, rules.String())
+			}
+		})
+	}
+}
+
+func TestWithName(t *testing.T) {
+	lh := &loggerHandler{}
+	WithName("billing")(lh)
+	if lh.name != "billing" {
+		t.Errorf("WithName() = %v, want %v", lh.name, "billing")
+	}
+}
+
+func TestNamed(t *testing.T) {
+	defaultLogger := slog.Default()
+	defer slog.SetDefault(defaultLogger)
+
+	tests := []struct {
+		name    string
+		handler slog.Handler
+		want    bool
+	}{
+		{name: "logger handler", handler: NewHandler(), want: true},
+		{name: "other handler", handler: slog.NewTextHandler(os.Stdout, nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slog.SetDefault(slog.New(tt.handler))
+
+			lh, ok := Named("billing").Handler().(*loggerHandler)
+			if ok != tt.want {
+				t.Fatalf("Named() is *loggerHandler = %v, want %v", ok, tt.want)
+			}
+			if ok && lh.name != "billing" {
+				t.Errorf("Named() = %v, want %v", lh.name, "billing")
+			}
+		})
+	}
+}
+
 func TestWithMaxLevelAddSource(t *testing.T) {
 	type args struct {
 		l slog.Level
@@ -156,6 +267,108 @@ func TestWithMaxLevelAddSource(t *testing.T) {
 	}
 }
 
+func TestWithTraceKeys(t *testing.T) {
+	lh := &loggerHandler{}
+	WithTraceKeys("traceId", "", "flags")(lh)
+
+	want := traceKeys{traceID: "traceId", traceFlags: "flags"}
+	if lh.traceKeys != want {
+		t.Errorf("WithTraceKeys() = %v, want %v", lh.traceKeys, want)
+	}
+}
+
+func TestWithLogIDFromTrace(t *testing.T) {
+	lh := &loggerHandler{}
+	WithLogIDFromTrace(true)(lh)
+	if !lh.logIDFromTrace {
+		t.Errorf("WithLogIDFromTrace() = %v, want %v", lh.logIDFromTrace, true)
+	}
+}
+
+func Test_loggerHandler_Handle_trace(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	type args struct {
+		ctx  context.Context
+		opts []Option
+	}
+	tests := []struct {
+		name string
+		args args
+		want map[string]any
+	}{
+		{
+			name: "without span",
+			args: args{ctx: context.Background()},
+			want: map[string]any{},
+		},
+		{
+			name: "with span",
+			args: args{ctx: trace.ContextWithSpanContext(context.Background(), sc)},
+			want: map[string]any{
+				"trace_id":    "0102030405060708090a0b0c0d0e0f10",
+				"span_id":     "0102030405060708",
+				"trace_flags": "01",
+			},
+		},
+		{
+			name: "with custom keys",
+			args: args{
+				ctx:  trace.ContextWithSpanContext(context.Background(), sc),
+				opts: []Option{WithTraceKeys("trace.id", "", "")},
+			},
+			want: map[string]any{
+				"trace.id": "0102030405060708090a0b0c0d0e0f10",
+			},
+		},
+		{
+			name: "with log id from trace",
+			args: args{
+				ctx: trace.ContextWithSpanContext(
+					context.WithValue(context.Background(), ContextLogID, "11111111-1111-1111-1111-111111111111"),
+					sc,
+				),
+				opts: []Option{WithTraceKeys("", "", ""), WithLogIDFromTrace(true)},
+			},
+			want: map[string]any{
+				"log": map[string]any{"id": "0102030405060708090a0b0c0d0e0f10"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := append([]Option{
+				WithMaxLevelAddSource(slog.LevelDebug),
+				WithHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+					ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+						if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+							return slog.Attr{}
+						}
+						return a
+					},
+				})),
+			}, tt.args.opts...)
+
+			NewLogger(opts...).InfoContext(tt.args.ctx, "message")
+
+			got := map[string]any{}
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("log = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_loggerHandler_Enabled(t *testing.T) {
 	type fields struct {
 		minLevel slog.Level
@@ -214,11 +427,22 @@ func Test_loggerHandler_Enabled(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "enable false, with context log level raising the minimum level",
+			fields: fields{
+				minLevel: slog.LevelDebug,
+			},
+			args: args{
+				ctx: context.WithValue(context.Background(), ContextMinLevel, slog.LevelWarn),
+				l:   slog.LevelInfo,
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			lh := &loggerHandler{
-				minLevel: tt.fields.minLevel,
+				level: NewLevelController(tt.fields.minLevel),
 			}
 			if got := lh.Enabled(tt.args.ctx, tt.args.l); got != tt.want {
 				t.Errorf("loggerHandler.Enabled() = %v, want %v", got, tt.want)
@@ -227,6 +451,81 @@ func Test_loggerHandler_Enabled(t *testing.T) {
 	}
 }
 
+func Test_loggerHandler_Enabled_rules(t *testing.T) {
+	rules, _ := ParseLevelRules("billing=debug")
+
+	lh := &loggerHandler{level: NewLevelController(slog.LevelInfo)}
+	if lh.Enabled(context.Background(), slog.LevelDebug) {
+		t.Errorf("loggerHandler.Enabled() = true, want false")
+	}
+
+	lh.level.SetRules(rules)
+	if !lh.Enabled(context.Background(), slog.LevelDebug) {
+		t.Errorf("loggerHandler.Enabled() = false, want true")
+	}
+}
+
+func Test_loggerHandler_Handle_rules(t *testing.T) {
+	type args struct {
+		rules    string
+		name     string
+		level    slog.Level
+		ctxLevel string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "global level",
+			args: args{rules: "billing=debug", level: slog.LevelDebug},
+			want: false,
+		},
+		{
+			name: "logger name",
+			args: args{rules: "billing=debug", name: "billing", level: slog.LevelDebug},
+			want: true,
+		},
+		{
+			name: "package",
+			args: args{rules: "github.com/telmoandrade/go-library/logger=debug", level: slog.LevelDebug},
+			want: true,
+		},
+		{
+			name: "context level overrides rules",
+			args: args{rules: "logger=error", level: slog.LevelWarn, ctxLevel: "warn"},
+			want: true,
+		},
+		{
+			name: "package raising the level",
+			args: args{rules: "logger=error", level: slog.LevelWarn},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseLevelRules(tt.args.rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			l := NewLogger(
+				WithName(tt.args.name),
+				WithLevelRules(rules),
+				WithHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+			)
+			ctx, _ := MinLevel(context.Background(), tt.args.ctxLevel)
+			l.Log(ctx, tt.args.level, "message")
+
+			if got := buf.Len() > 0; got != tt.want {
+				t.Errorf("loggerHandler.Handle() logged = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_loggerHandler_Handle(t *testing.T) {
 	type fields struct {
 		maxLevelAddSource slog.Level
@@ -259,14 +558,14 @@ func Test_loggerHandler_Handle(t *testing.T) {
 			fields: fields{
 				maxLevelAddSource: slog.LevelInfo,
 			},
-			args: args{ctx: context.WithValue(context.Background(), ContextLogID, uuid.Nil)},
+			args: args{ctx: context.WithValue(context.Background(), ContextLogID, "")},
 		},
 		{
 			name: "with log",
 			fields: fields{
 				maxLevelAddSource: slog.LevelInfo,
 			},
-			args: args{ctx: context.WithValue(context.Background(), ContextLogID, uuid.Must(uuid.NewV7()))},
+			args: args{ctx: context.WithValue(context.Background(), ContextLogID, GenerateUUIDv7())},
 		},
 	}
 	for _, tt := range tests {
@@ -279,6 +578,7 @@ func Test_loggerHandler_Handle(t *testing.T) {
 
 			lh := &loggerHandler{
 				handler:           mock,
+				level:             &LevelController{},
 				maxLevelAddSource: tt.fields.maxLevelAddSource,
 			}
 
@@ -303,7 +603,12 @@ func Test_loggerHandler_WithAttrs(t *testing.T) {
 	lh := &loggerHandler{
 		handler: mock,
 	}
-	lh.WithAttrs([]slog.Attr{})
+	if got := lh.WithAttrs([]slog.Attr{}); got != lh {
+		t.Errorf("loggerHandler.WithAttrs() = %v, want %v", got, lh)
+	}
+	if _, ok := lh.WithAttrs([]slog.Attr{slog.String("k", "v")}).(*loggerHandler); !ok {
+		t.Errorf("loggerHandler.WithAttrs() is not *loggerHandler")
+	}
 }
 
 func Test_loggerHandler_WithGroup(t *testing.T) {
@@ -311,10 +616,102 @@ func Test_loggerHandler_WithGroup(t *testing.T) {
 	defer ctrl.Finish()
 
 	mock := NewMockSlogHandler(ctrl)
-	mock.EXPECT().WithGroup(gomock.Any()).Times(1)
+	mock.EXPECT().WithGroup(gomock.Any()).Times(0)
 
 	lh := &loggerHandler{
 		handler: mock,
 	}
-	lh.WithGroup("group")
+	if got := lh.WithGroup(""); got != lh {
+		t.Errorf("loggerHandler.WithGroup() = %v, want %v", got, lh)
+	}
+	if _, ok := lh.WithGroup("group").(*loggerHandler); !ok {
+		t.Errorf("loggerHandler.WithGroup() is not *loggerHandler")
+	}
+}
+
+func Test_loggerHandler_composition(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  func(ctx context.Context) context.Context
+		fn   func(l *slog.Logger) *slog.Logger
+		want map[string]any
+	}{
+		{
+			name: "without group",
+			fn: func(l *slog.Logger) *slog.Logger {
+				return l.With("a", "1")
+			},
+			want: map[string]any{
+				"a":   "1",
+				"k":   "v",
+				"log": map[string]any{"id": "11111111-1111-1111-1111-111111111111"},
+			},
+		},
+		{
+			name: "with group",
+			fn: func(l *slog.Logger) *slog.Logger {
+				return l.With("a", "1").WithGroup("g").With("b", "2")
+			},
+			want: map[string]any{
+				"a":   "1",
+				"g":   map[string]any{"b": "2", "k": "v"},
+				"log": map[string]any{"id": "11111111-1111-1111-1111-111111111111"},
+			},
+		},
+		{
+			name: "with context attrs",
+			ctx: func(ctx context.Context) context.Context {
+				return AppendCtx(ctx, slog.String("tenant", "t1"))
+			},
+			fn: func(l *slog.Logger) *slog.Logger {
+				return l.WithGroup("g")
+			},
+			want: map[string]any{
+				"g":      map[string]any{"k": "v"},
+				"tenant": "t1",
+				"log":    map[string]any{"id": "11111111-1111-1111-1111-111111111111"},
+			},
+		},
+		{
+			name: "with nested group",
+			fn: func(l *slog.Logger) *slog.Logger {
+				return l.WithGroup("g1").With("b", "2").WithGroup("g2")
+			},
+			want: map[string]any{
+				"g1":  map[string]any{"b": "2", "g2": map[string]any{"k": "v"}},
+				"log": map[string]any{"id": "11111111-1111-1111-1111-111111111111"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := NewLogger(
+				WithMinLevel(slog.LevelInfo),
+				WithMaxLevelAddSource(slog.LevelDebug),
+				WithHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+					ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+						if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+							return slog.Attr{}
+						}
+						return a
+					},
+				})),
+			)
+
+			ctx := context.WithValue(context.Background(), ContextLogID, "11111111-1111-1111-1111-111111111111")
+			if tt.ctx != nil {
+				ctx = tt.ctx(ctx)
+			}
+			tt.fn(l).InfoContext(ctx, "message", "k", "v")
+
+			got := map[string]any{}
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("log = %v, want %v", got, tt.want)
+			}
+		})
+	}
 }
@@ -0,0 +1,282 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// LevelController controls at runtime the minimum log level and the [LevelRules] of the handlers created with [NewHandler].
+	// It is backed by an atomic [slog.LevelVar], so changing the level is safe while logs are being processed.
+	//
+	// LevelController implements [http.Handler] to inspect and change the level through HTTP:
+	//   - GET: Responds with the current level and rules, for example {"level":"INFO","rules":"billing=debug"}.
+	//   - PUT: Changes the level from a body like {"level":"debug","ttl":"5m"}, the ttl is optional and reverts the level when it expires.
+	//     The rules are changed when present in the body, like {"rules":"billing=debug"}.
+	LevelController struct {
+		levelVar  slog.LevelVar
+		rules     atomic.Pointer[LevelRules]
+		mu        sync.Mutex
+		baseLevel slog.Level
+		revertAt  time.Time
+		timer     *time.Timer
+	}
+
+	levelControllerBody struct {
+		Level    string     `json:"level,omitempty"`
+		Rules    *string    `json:"rules,omitempty"`
+		TTL      string     `json:"ttl,omitempty"`
+		RevertAt *time.Time `json:"revertAt,omitempty"`
+	}
+
+	levelControllerError struct {
+		Error string `json:"error"`
+	}
+)
+
+// Custom levels in addition to the levels of the [slog] package, they are accepted by [MinLevel] and rendered
+// with their names by [ReplaceLevelNames].
+const (
+	// LevelTrace is used for very detailed information, such as the payloads of the requests, see [Trace].
+	LevelTrace = slog.Level(-8)
+	// LevelNotice is used for normal but significant events, such as a configuration change.
+	LevelNotice = slog.Level(2)
+	// LevelFatal is used for errors that stop the application, see [Fatal].
+	LevelFatal = slog.Level(12)
+)
+
+var _ http.Handler = &LevelController{}
+
+// levelNames is sorted by level, each level is named after the nearest named level below it.
+var levelNames = []struct {
+	name  string
+	level slog.Level
+}{
+	{"TRACE", LevelTrace},
+	{"DEBUG", slog.LevelDebug},
+	{"INFO", slog.LevelInfo},
+	{"NOTICE", LevelNotice},
+	{"WARN", slog.LevelWarn},
+	{"ERROR", slog.LevelError},
+	{"FATAL", LevelFatal},
+}
+
+// parseLevel parses a level name, a level name with an offset like debug+2, or a number like -8.
+func parseLevel(level string) (slog.Level, error) {
+	if n, err := strconv.Atoi(level); err == nil {
+		return slog.Level(n), nil
+	}
+
+	name, offset := level, 0
+	if i := strings.IndexAny(level, "+-"); i > 0 {
+		n, err := strconv.Atoi(level[i:])
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q must be trace, debug, info, notice, warn, error, fatal or a number", ErrInvalidMinLevel, level)
+		}
+		name, offset = level[:i], n
+	}
+
+	for _, ln := range levelNames {
+		if strings.EqualFold(name, ln.name) {
+			return ln.level + slog.Level(offset), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q must be trace, debug, info, notice, warn, error, fatal or a number", ErrInvalidMinLevel, level)
+}
+
+// LevelName returns the name of the level like [slog.Level.String], including the custom levels, such as TRACE and NOTICE+1.
+// The name is accepted by [MinLevel].
+func LevelName(level slog.Level) string {
+	i := len(levelNames) - 1
+	for i > 0 && level < levelNames[i].level {
+		i--
+	}
+
+	ln := levelNames[i]
+	if level == ln.level {
+		return ln.name
+	}
+	return fmt.Sprintf("%s%+d", ln.name, int(level-ln.level))
+}
+
+// ReplaceLevelNames is a function for [slog.HandlerOptions.ReplaceAttr] that renders the custom levels with their names,
+// such as TRACE instead of DEBUG-4.
+// It is used by the default handler of [NewHandler], and can be called from another ReplaceAttr function.
+//
+// Example:
+//
+//	logger.WithHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{ReplaceAttr: logger.ReplaceLevelNames}))
+func ReplaceLevelNames(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && a.Key == slog.LevelKey {
+		if level, ok := a.Value.Any().(slog.Level); ok {
+			a.Value = slog.StringValue(LevelName(level))
+		}
+	}
+	return a
+}
+
+// NewLevelController returns a new [LevelController] with the initial minimum log level.
+// Use [WithLevelController] to bind it to one or more handlers.
+func NewLevelController(level slog.Level) *LevelController {
+	lc := &LevelController{}
+	lc.levelVar.Set(level)
+	return lc
+}
+
+// Level returns the current minimum log level.
+func (lc *LevelController) Level() slog.Level {
+	return lc.levelVar.Level()
+}
+
+// SetLevel changes the minimum log level, discarding any pending revert scheduled by [LevelController.SetLevelTTL].
+func (lc *LevelController) SetLevel(level slog.Level) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.stopTimer()
+	lc.levelVar.Set(level)
+}
+
+// SetLevelTTL changes the minimum log level for the duration of the ttl.
+// When the ttl expires, the level reverts to the one in effect before the first pending change.
+//
+// Behavior:
+//   - If the ttl is less than or equal to zero, it behaves like [LevelController.SetLevel].
+func (lc *LevelController) SetLevelTTL(level slog.Level, ttl time.Duration) {
+	if ttl <= 0 {
+		lc.SetLevel(level)
+		return
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if lc.timer == nil {
+		lc.baseLevel = lc.levelVar.Level()
+	} else {
+		lc.timer.Stop()
+	}
+
+	lc.levelVar.Set(level)
+	lc.revertAt = time.Now().Add(ttl)
+
+	var timer *time.Timer
+	timer = time.AfterFunc(ttl, func() {
+		lc.mu.Lock()
+		defer lc.mu.Unlock()
+
+		if lc.timer == timer {
+			lc.levelVar.Set(lc.baseLevel)
+			lc.timer = nil
+			lc.revertAt = time.Time{}
+		}
+	})
+	lc.timer = timer
+}
+
+// Rules returns the current [LevelRules].
+func (lc *LevelController) Rules() LevelRules {
+	if lr := lc.rules.Load(); lr != nil {
+		return *lr
+	}
+	return LevelRules{}
+}
+
+// SetRules replaces the [LevelRules], an empty value removes all rules.
+func (lc *LevelController) SetRules(rules LevelRules) {
+	lc.rules.Store(&rules)
+}
+
+func (lc *LevelController) stopTimer() {
+	if lc.timer != nil {
+		lc.timer.Stop()
+		lc.timer = nil
+		lc.revertAt = time.Time{}
+	}
+}
+
+func (lc *LevelController) body() levelControllerBody {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	rules := lc.Rules().String()
+	body := levelControllerBody{
+		Level: LevelName(lc.levelVar.Level()),
+		Rules: &rules,
+	}
+	if lc.timer != nil {
+		revertAt := lc.revertAt
+		body.RevertAt = &revertAt
+	}
+	return body
+}
+
+func levelControllerWrite(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (lc *LevelController) servePut(w http.ResponseWriter, r *http.Request) {
+	body := levelControllerBody{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		levelControllerWrite(w, http.StatusBadRequest, levelControllerError{Error: fmt.Sprintf("invalid body: %s", err.Error())})
+		return
+	}
+
+	if body.Rules != nil {
+		rules, err := ParseLevelRules(*body.Rules)
+		if err != nil {
+			levelControllerWrite(w, http.StatusBadRequest, levelControllerError{Error: err.Error()})
+			return
+		}
+
+		lc.SetRules(rules)
+		slog.InfoContext(r.Context(), fmt.Sprintf("[LOGGER] Level rules changed to %q", rules.String()))
+
+		if body.Level == "" {
+			levelControllerWrite(w, http.StatusOK, lc.body())
+			return
+		}
+	}
+
+	level, err := parseLevel(body.Level)
+	if err != nil {
+		levelControllerWrite(w, http.StatusBadRequest, levelControllerError{Error: err.Error()})
+		return
+	}
+
+	var ttl time.Duration
+	if body.TTL != "" {
+		ttl, err = time.ParseDuration(body.TTL)
+		if err != nil || ttl < 0 {
+			levelControllerWrite(w, http.StatusBadRequest, levelControllerError{Error: fmt.Sprintf("invalid ttl: %q", body.TTL)})
+			return
+		}
+	}
+
+	lc.SetLevelTTL(level, ttl)
+	slog.InfoContext(r.Context(), fmt.Sprintf("[LOGGER] Level changed to %s", LevelName(level)), slog.Duration("ttl", ttl))
+
+	levelControllerWrite(w, http.StatusOK, lc.body())
+}
+
+// ServeHTTP responds with the current level on GET and changes the level on PUT.
+func (lc *LevelController) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		levelControllerWrite(w, http.StatusOK, lc.body())
+	case http.MethodPut:
+		lc.servePut(w, r)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		levelControllerWrite(w, http.StatusMethodNotAllowed, levelControllerError{Error: "method not allowed"})
+	}
+}
@@ -0,0 +1,137 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+type (
+	// LevelRules defines minimum log levels by logger name or by package, overriding the global minimum level.
+	// Use [ParseLevelRules] to create it, the zero value has no rules.
+	//
+	// Each rule key is matched against:
+	//   - Logger name: The name defined by [Named] or [WithName], matched exactly or as a dotted prefix (billing matches billing.invoice).
+	//   - Package: The package of the function that generated the log, matched by whole path segments
+	//     (billing matches github.com/foo/billing and github.com/foo/billing/invoice, github.com/foo matches github.com/foo/bar).
+	//
+	// When several rules match, the rule with the longest key wins.
+	LevelRules struct {
+		rules    []levelRule
+		minLevel slog.Level
+	}
+
+	levelRule struct {
+		key   string
+		level slog.Level
+	}
+)
+
+// ErrInvalidLevelRules is returned by [ParseLevelRules] when the rules are not in the format key=level.
+var ErrInvalidLevelRules = errors.New("invalid level rules")
+
+// ParseLevelRules returns the [LevelRules] from a comma separated list of key=level, for example billing=debug,github.com/foo=warn.
+// The level can be one of the options accepted by [MinLevel].
+func ParseLevelRules(rules string) (LevelRules, error) {
+	lr := LevelRules{}
+
+	for _, rule := range strings.Split(rules, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(rule, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return LevelRules{}, fmt.Errorf("%w: %q", ErrInvalidLevelRules, rule)
+		}
+
+		level, err := parseLevel(strings.TrimSpace(value))
+		if err != nil {
+			return LevelRules{}, fmt.Errorf("%w: %q: %w", ErrInvalidLevelRules, rule, err)
+		}
+
+		lr.rules = slices.DeleteFunc(lr.rules, func(r levelRule) bool { return r.key == key })
+		lr.rules = append(lr.rules, levelRule{key: key, level: level})
+	}
+
+	for i, r := range lr.rules {
+		if i == 0 || r.level < lr.minLevel {
+			lr.minLevel = r.level
+		}
+	}
+
+	return lr, nil
+}
+
+// String returns the rules in the format accepted by [ParseLevelRules].
+func (lr LevelRules) String() string {
+	rules := make([]string, 0, len(lr.rules))
+	for _, r := range lr.rules {
+		rules = append(rules, fmt.Sprintf("%s=%s", r.key, strings.ToLower(LevelName(r.level))))
+	}
+	return strings.Join(rules, ",")
+}
+
+func (lr LevelRules) empty() bool {
+	return len(lr.rules) == 0
+}
+
+func levelRuleMatchName(key, name string) bool {
+	return name != "" && (name == key || strings.HasPrefix(name, key+"."))
+}
+
+func levelRuleMatchPackage(key, pkg string) bool {
+	return pkg != "" && (pkg == key ||
+		strings.HasPrefix(pkg, key+"/") ||
+		strings.HasSuffix(pkg, "/"+key) ||
+		strings.Contains(pkg, "/"+key+"/"))
+}
+
+// match returns the level of the most specific rule for the logger name or the package.
+func (lr LevelRules) match(name, pkg string) (slog.Level, bool) {
+	var level slog.Level
+	size := -1
+	for _, r := range lr.rules {
+		if len(r.key) > size && (levelRuleMatchName(r.key, name) || levelRuleMatchPackage(r.key, pkg)) {
+			level = r.level
+			size = len(r.key)
+		}
+	}
+	return level, size >= 0
+}
+
+// functionPackage returns the package path of a fully qualified function name,
+// for example github.com/foo/billing.(*Service).Charge returns github.com/foo/billing.
+//
+// The runtime escapes the dots of the last path element, gopkg.in/yaml.v3.Marshal is reported as gopkg.in/yaml%2ev3.Marshal.
+// Without the escape, an element like v3 following the package name is kept as part of the path.
+func functionPackage(function string) string {
+	i := strings.LastIndexByte(function, '/') + 1
+	rest := function[i:]
+
+	// The receiver, the function and the closures follow the first dot of the last path element.
+	elems := strings.Split(rest, ".")
+	pkg := elems[0]
+	if len(elems) > 2 && isVersionElem(elems[1]) {
+		pkg += "." + elems[1]
+	}
+
+	return function[:i] + strings.ReplaceAll(pkg, "%2e", ".")
+}
+
+// isVersionElem reports whether the element is a major version suffix, like v3 of gopkg.in/yaml.v3.
+func isVersionElem(elem string) bool {
+	if len(elem) < 2 || elem[0] != 'v' {
+		return false
+	}
+	for _, c := range elem[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
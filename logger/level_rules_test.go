@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+func TestParseLevelRules(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         string
+		want         string
+		wantMinLevel slog.Level
+		wantErr      error
+	}{
+		{name: "empty", args: "", want: ""},
+		{name: "one rule", args: "billing=debug", want: "billing=debug", wantMinLevel: slog.LevelDebug},
+		{
+			name:         "many rules",
+			args:         " billing = debug , github.com/foo=WARN,",
+			want:         "billing=debug,github.com/foo=warn",
+			wantMinLevel: slog.LevelDebug,
+		},
+		{name: "custom level", args: "billing=trace,audit=-2", want: "billing=trace,audit=debug+2", wantMinLevel: LevelTrace},
+		{name: "duplicate key", args: "billing=debug,billing=error", want: "billing=error", wantMinLevel: slog.LevelError},
+		{name: "without level", args: "billing", wantErr: ErrInvalidLevelRules},
+		{name: "without key", args: "=debug", wantErr: ErrInvalidLevelRules},
+		{name: "invalid level", args: "billing=invalid", wantErr: ErrInvalidMinLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLevelRules(tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseLevelRules() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.String() != tt.want {
+				t.Errorf("ParseLevelRules() = %v, want %v", got.String(), tt.want)
+			}
+			if got.minLevel != tt.wantMinLevel {
+				t.Errorf("ParseLevelRules().minLevel = %v, want %v", got.minLevel, tt.wantMinLevel)
+			}
+		})
+	}
+}
+
+func TestLevelRules_match(t *testing.T) {
+	rules, err := ParseLevelRules("billing=debug,github.com/foo=warn,github.com/foo/bar=error")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type args struct {
+		name string
+		pkg  string
+	}
+	type want struct {
+		level slog.Level
+		ok    bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{name: "no match", args: args{name: "other", pkg: "github.com/other"}},
+		{name: "logger name", args: args{name: "billing"}, want: want{level: slog.LevelDebug, ok: true}},
+		{name: "logger name prefix", args: args{name: "billing.invoice"}, want: want{level: slog.LevelDebug, ok: true}},
+		{name: "logger name not prefix", args: args{name: "billingx"}},
+		{name: "package suffix", args: args{pkg: "github.com/org/billing"}, want: want{level: slog.LevelDebug, ok: true}},
+		{name: "package segment", args: args{pkg: "github.com/org/billing/invoice"}, want: want{level: slog.LevelDebug, ok: true}},
+		{name: "package prefix", args: args{pkg: "github.com/foo/baz"}, want: want{level: slog.LevelWarn, ok: true}},
+		{name: "package most specific", args: args{pkg: "github.com/foo/bar/baz"}, want: want{level: slog.LevelError, ok: true}},
+		{name: "package not segment", args: args{pkg: "github.com/foobar"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := rules.match(tt.args.name, tt.args.pkg)
+			if ok != tt.want.ok || got != tt.want.level {
+				t.Errorf("LevelRules.match() = %v, %v, want %v, %v", got, ok, tt.want.level, tt.want.ok)
+			}
+		})
+	}
+}
+
+func Test_functionPackage(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{name: "empty", args: "", want: ""},
+		{name: "main", args: "main.main", want: "main"},
+		{name: "function", args: "github.com/foo/billing.Charge", want: "github.com/foo/billing"},
+		{name: "method", args: "github.com/foo/billing.(*Service).Charge", want: "github.com/foo/billing"},
+		{name: "closure", args: "github.com/foo/billing.Charge.func1", want: "github.com/foo/billing"},
+		{name: "dotted path", args: "gopkg.in/foo.v1/bar.Baz", want: "gopkg.in/foo.v1/bar"},
+		{name: "dotted package", args: "gopkg.in/yaml.v3.Marshal", want: "gopkg.in/yaml.v3"},
+		{name: "dotted package method", args: "gopkg.in/yaml.v3.(*decoder).unmarshal", want: "gopkg.in/yaml.v3"},
+		{name: "escaped dotted package", args: "gopkg.in/yaml%2ev3.(*decoder).unmarshal.func1", want: "gopkg.in/yaml.v3"},
+		{name: "escaped dotted package value method", args: "example.com/a/sub%2ev3.T.M", want: "example.com/a/sub.v3"},
+		{name: "major version", args: "example.com/a/v2.Func", want: "example.com/a/v2"},
+		{name: "major version method", args: "example.com/a/v2.(*T).Method.func1", want: "example.com/a/v2"},
+		{name: "value method", args: "example.com/a.T.Method", want: "example.com/a"},
+		{name: "version function", args: "example.com/a.v2", want: "example.com/a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := functionPackage(tt.args); got != tt.want {
+				t.Errorf("functionPackage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    string
+		want    slog.Level
+		wantErr error
+	}{
+		{name: "empty", args: "", wantErr: ErrInvalidMinLevel},
+		{name: "debug", args: "debug", want: slog.LevelDebug},
+		{name: "info upper", args: "INFO", want: slog.LevelInfo},
+		{name: "warn", args: "warn", want: slog.LevelWarn},
+		{name: "error", args: "error", want: slog.LevelError},
+		{name: "trace", args: "trace", want: LevelTrace},
+		{name: "notice", args: "Notice", want: LevelNotice},
+		{name: "fatal", args: "FATAL", want: LevelFatal},
+		{name: "number", args: "-8", want: LevelTrace},
+		{name: "positive number", args: "+3", want: slog.Level(3)},
+		{name: "name with offset", args: "debug+2", want: slog.Level(-2)},
+		{name: "name with negative offset", args: "TRACE-1", want: slog.Level(-9)},
+		{name: "invalid offset", args: "debug+x", wantErr: ErrInvalidMinLevel},
+		{name: "invalid name with offset", args: "verbose+1", wantErr: ErrInvalidMinLevel},
+		{name: "invalid", args: "invalid", wantErr: ErrInvalidMinLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLevel(tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseLevel() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevelName(t *testing.T) {
+	tests := []struct {
+		name string
+		args slog.Level
+		want string
+	}{
+		{name: "below trace", args: LevelTrace - 2, want: "TRACE-2"},
+		{name: "trace", args: LevelTrace, want: "TRACE"},
+		{name: "above trace", args: LevelTrace + 1, want: "TRACE+1"},
+		{name: "debug", args: slog.LevelDebug, want: "DEBUG"},
+		{name: "info", args: slog.LevelInfo, want: "INFO"},
+		{name: "notice", args: LevelNotice, want: "NOTICE"},
+		{name: "above notice", args: LevelNotice + 1, want: "NOTICE+1"},
+		{name: "warn", args: slog.LevelWarn, want: "WARN"},
+		{name: "error", args: slog.LevelError, want: "ERROR"},
+		{name: "fatal", args: LevelFatal, want: "FATAL"},
+		{name: "above fatal", args: LevelFatal + 4, want: "FATAL+4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LevelName(tt.args); got != tt.want {
+				t.Errorf("LevelName() = %v, want %v", got, tt.want)
+			}
+			if got, err := parseLevel(tt.want); err != nil || got != tt.args {
+				t.Errorf("parseLevel() = %v, %v, want %v", got, err, tt.args)
+			}
+		})
+	}
+}
+
+func TestReplaceLevelNames(t *testing.T) {
+	type args struct {
+		groups []string
+		a      slog.Attr
+	}
+	tests := []struct {
+		name string
+		args args
+		want slog.Attr
+	}{
+		{name: "level", args: args{a: slog.Any(slog.LevelKey, LevelTrace)}, want: slog.String(slog.LevelKey, "TRACE")},
+		{name: "level in group", args: args{groups: []string{"g"}, a: slog.Any(slog.LevelKey, LevelTrace)}, want: slog.Any(slog.LevelKey, LevelTrace)},
+		{name: "level not a level", args: args{a: slog.String(slog.LevelKey, "high")}, want: slog.String(slog.LevelKey, "high")},
+		{name: "other key", args: args{a: slog.Any("other", LevelTrace)}, want: slog.Any("other", LevelTrace)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReplaceLevelNames(tt.args.groups, tt.args.a); !got.Equal(tt.want) {
+				t.Errorf("ReplaceLevelNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewLevelController(t *testing.T) {
+	lc := NewLevelController(slog.LevelWarn)
+	if got := lc.Level(); got != slog.LevelWarn {
+		t.Errorf("LevelController.Level() = %v, want %v", got, slog.LevelWarn)
+	}
+
+	var zero LevelController
+	if got := zero.Level(); got != slog.LevelInfo {
+		t.Errorf("LevelController.Level() = %v, want %v", got, slog.LevelInfo)
+	}
+}
+
+func TestLevelController_SetLevel(t *testing.T) {
+	lc := NewLevelController(slog.LevelInfo)
+	lc.SetLevelTTL(slog.LevelDebug, time.Hour)
+	lc.SetLevel(slog.LevelError)
+
+	if got := lc.Level(); got != slog.LevelError {
+		t.Errorf("LevelController.Level() = %v, want %v", got, slog.LevelError)
+	}
+	if lc.timer != nil {
+		t.Errorf("LevelController.timer = %v, want nil", lc.timer)
+	}
+}
+
+func TestLevelController_SetLevelTTL(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		wantLevel slog.Level
+		wantAfter slog.Level
+	}{
+		{
+			name:      "without ttl",
+			wantLevel: slog.LevelDebug,
+			wantAfter: slog.LevelDebug,
+		},
+		{
+			name:      "with ttl",
+			ttl:       10 * time.Millisecond,
+			wantLevel: slog.LevelDebug,
+			wantAfter: slog.LevelInfo,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := NewLevelController(slog.LevelInfo)
+			lc.SetLevelTTL(slog.LevelDebug, tt.ttl)
+
+			if got := lc.Level(); got != tt.wantLevel {
+				t.Errorf("LevelController.Level() = %v, want %v", got, tt.wantLevel)
+			}
+
+			time.Sleep(50 * time.Millisecond)
+
+			if got := lc.Level(); got != tt.wantAfter {
+				t.Errorf("LevelController.Level() after ttl = %v, want %v", got, tt.wantAfter)
+			}
+		})
+	}
+}
+
+func TestLevelController_SetLevelTTL_nested(t *testing.T) {
+	lc := NewLevelController(slog.LevelInfo)
+	lc.SetLevelTTL(slog.LevelDebug, time.Hour)
+	lc.SetLevelTTL(slog.LevelWarn, 10*time.Millisecond)
+
+	time.Sleep(50 * time.Millisecond)
+
+	if got := lc.Level(); got != slog.LevelInfo {
+		t.Errorf("LevelController.Level() = %v, want %v", got, slog.LevelInfo)
+	}
+}
+
+func TestLevelController_SetRules(t *testing.T) {
+	lc := NewLevelController(slog.LevelInfo)
+	if got := lc.Rules(); !got.empty() {
+		t.Errorf("LevelController.Rules() = %v, want empty", got)
+	}
+
+	rules, _ := ParseLevelRules("billing=debug")
+	lc.SetRules(rules)
+	if got := lc.Rules().String(); got != "billing=debug" {
+		t.Errorf("LevelController.Rules() = %v, want %v", got, "billing=debug")
+	}
+}
+
+func TestLevelController_ServeHTTP(t *testing.T) {
+	type args struct {
+		method string
+		body   string
+	}
+	type want struct {
+		statusCode int
+		level      string
+		revertAt   bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "get",
+			args: args{method: http.MethodGet},
+			want: want{statusCode: http.StatusOK, level: "INFO"},
+		},
+		{
+			name: "put",
+			args: args{method: http.MethodPut, body: `{"level":"debug"}`},
+			want: want{statusCode: http.StatusOK, level: "DEBUG"},
+		},
+		{
+			name: "put with ttl",
+			args: args{method: http.MethodPut, body: `{"level":"warn","ttl":"1m"}`},
+			want: want{statusCode: http.StatusOK, level: "WARN", revertAt: true},
+		},
+		{
+			name: "put rules",
+			args: args{method: http.MethodPut, body: `{"rules":"billing=debug"}`},
+			want: want{statusCode: http.StatusOK, level: "INFO"},
+		},
+		{
+			name: "put rules and level",
+			args: args{method: http.MethodPut, body: `{"level":"warn","rules":"billing=debug"}`},
+			want: want{statusCode: http.StatusOK, level: "WARN"},
+		},
+		{
+			name: "put invalid rules",
+			args: args{method: http.MethodPut, body: `{"rules":"billing"}`},
+			want: want{statusCode: http.StatusBadRequest},
+		},
+		{
+			name: "put invalid body",
+			args: args{method: http.MethodPut, body: `{`},
+			want: want{statusCode: http.StatusBadRequest},
+		},
+		{
+			name: "put invalid level",
+			args: args{method: http.MethodPut, body: `{"level":"invalid"}`},
+			want: want{statusCode: http.StatusBadRequest},
+		},
+		{
+			name: "put invalid ttl",
+			args: args{method: http.MethodPut, body: `{"level":"debug","ttl":"invalid"}`},
+			want: want{statusCode: http.StatusBadRequest},
+		},
+		{
+			name: "post",
+			args: args{method: http.MethodPost},
+			want: want{statusCode: http.StatusMethodNotAllowed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := NewLevelController(slog.LevelInfo)
+			defer lc.SetLevel(slog.LevelInfo)
+
+			r := httptest.NewRequest(tt.args.method, "/", strings.NewReader(tt.args.body))
+			w := httptest.NewRecorder()
+
+			lc.ServeHTTP(w, r)
+
+			if w.Code != tt.want.statusCode {
+				t.Fatalf("Code() = %v, want %v", w.Code, tt.want.statusCode)
+			}
+			if tt.want.statusCode != http.StatusOK {
+				return
+			}
+
+			body := levelControllerBody{}
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if body.Level != tt.want.level {
+				t.Errorf("Body.Level = %v, want %v", body.Level, tt.want.level)
+			}
+			if (body.RevertAt != nil) != tt.want.revertAt {
+				t.Errorf("Body.RevertAt = %v, want %v", body.RevertAt, tt.want.revertAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"runtime"
+	"time"
+)
+
+// flusher is implemented by the handlers holding records to be delivered, such as [AsyncHandler].
+type flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// closer is implemented by the handlers holding resources to be released, such as [AsyncHandler].
+type closer interface {
+	Close(ctx context.Context) error
+}
+
+const fatalFlushTimeout = 5 * time.Second
+
+// osExit is replaced in tests.
+var osExit = os.Exit
+
+// Trace logs at [LevelTrace] with the default logger, like [slog.DebugContext].
+// With another logger, use [slog.Logger.Log] with [LevelTrace].
+func Trace(ctx context.Context, msg string, args ...any) {
+	logAt(ctx, slog.Default(), LevelTrace, msg, args...)
+}
+
+// Fatal logs at [LevelFatal] with the default logger and exits the application with status 1.
+//
+// Behavior:
+//   - The records buffered by [WithDebugOnError] in the context are logged before exiting.
+//   - The handler is flushed before exiting when it holds records to be delivered, such as [AsyncHandler],
+//     waiting up to 5 seconds.
+//
+// Important Note:
+//   - Deferred functions are not run, prefer returning the error to the main function when possible.
+func Fatal(ctx context.Context, msg string, args ...any) {
+	l := slog.Default()
+	logAt(ctx, l, LevelFatal, msg, args...)
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fatalFlushTimeout)
+	defer cancel()
+
+	_ = FlushLogBuffer(ctx)
+	if f, ok := l.Handler().(flusher); ok {
+		_ = f.Flush(ctx)
+	}
+
+	osExit(1)
+}
+
+// logAt logs the record with the source of the caller of the exported function.
+func logAt(ctx context.Context, l *slog.Logger, level slog.Level, msg string, args ...any) {
+	if !l.Enabled(ctx, level) {
+		return
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // skip [runtime.Callers, logAt, Trace or Fatal]
+
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	r.Add(args...)
+	_ = l.Handler().Handle(ctx, r)
+}
+
+// Flush logs the pending summary of [WithSampling] and flushes the handler when it holds records to be delivered, such as [AsyncHandler].
+func (lh *loggerHandler) Flush(ctx context.Context) error {
+	if lh.sampler != nil {
+		lh.sampler.flush(false)
+	}
+	if f, ok := lh.handler.(flusher); ok {
+		return f.Flush(ctx)
+	}
+	return nil
+}
+
+// Close stops the summary timer of [WithSampling] logging the pending summary,
+// and closes the handler when it is closable, such as [AsyncHandler].
+func (lh *loggerHandler) Close(ctx context.Context) error {
+	if lh.sampler != nil {
+		lh.sampler.flush(true)
+	}
+	if c, ok := lh.handler.(closer); ok {
+		return c.Close(ctx)
+	}
+	return nil
+}
+
+// Flush flushes every sink holding records to be delivered, such as [AsyncHandler], the errors are joined.
+func (mh *multiHandler) Flush(ctx context.Context) error {
+	var errs []error
+	for _, s := range mh.sinks {
+		if f, ok := s.handler.(flusher); ok {
+			if err := f.Flush(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
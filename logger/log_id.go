@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// LogIDGenerator returns a new log identifier.
+	// It is used as a parameter for the [LogIdWith].
+	LogIDGenerator func() string
+
+	// LogIDParser validates a propagated log identifier, returning it in its canonical form.
+	// It is used as a parameter for the [LogIdWith].
+	LogIDParser func(id string) (string, error)
+)
+
+// ErrInvalidLogID is returned by a [LogIDParser] when the propagated log identifier is not valid.
+var ErrInvalidLogID = errors.New("invalid log id")
+
+const (
+	// maxLogIDLength is the maximum length of a propagated log identifier.
+	maxLogIDLength = 128
+
+	ulidEncoding = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+// GenerateUUIDv4 is a [LogIDGenerator] that returns a random UUID version 4.
+func GenerateUUIDv4() string {
+	return uuid.NewString()
+}
+
+// GenerateUUIDv7 is a [LogIDGenerator] that returns a time ordered UUID version 7.
+func GenerateUUIDv7() string {
+	u, err := uuid.NewV7()
+	if err != nil {
+		return uuid.NewString()
+	}
+	return u.String()
+}
+
+// GenerateULID is a [LogIDGenerator] that returns a time ordered ULID, like 01ARZ3NDEKTSV4RRFFQ69G5FAV.
+func GenerateULID() string {
+	var b [16]byte
+	ms := uint64(time.Now().UnixMilli())
+	binary.BigEndian.PutUint16(b[0:], uint16(ms>>32))
+	binary.BigEndian.PutUint32(b[2:], uint32(ms))
+	_, _ = rand.Read(b[6:])
+
+	hi := binary.BigEndian.Uint64(b[0:])
+	lo := binary.BigEndian.Uint64(b[8:])
+
+	var s [26]byte
+	for i := range s {
+		shift := uint(25-i) * 5
+		var v uint64
+		switch {
+		case shift >= 64:
+			v = hi >> (shift - 64)
+		case shift == 0:
+			v = lo
+		default:
+			v = lo>>shift | hi<<(64-shift)
+		}
+		s[i] = ulidEncoding[v&31]
+	}
+	return string(s[:])
+}
+
+// ParseUUID is a [LogIDParser] that accepts any UUID version, except the nil UUID.
+func ParseUUID(id string) (string, error) {
+	u, err := uuid.Parse(id)
+	if err != nil || u == uuid.Nil {
+		return "", fmt.Errorf("%w: %q is not a UUID", ErrInvalidLogID, id)
+	}
+	return u.String(), nil
+}
+
+// ParseULID is a [LogIDParser] that accepts a ULID, case insensitive, returning it in upper case.
+func ParseULID(id string) (string, error) {
+	id = strings.ToUpper(id)
+	if len(id) != 26 || id[0] > '7' {
+		return "", fmt.Errorf("%w: %q is not a ULID", ErrInvalidLogID, id)
+	}
+	for i := 0; i < len(id); i++ {
+		if strings.IndexByte(ulidEncoding, id[i]) < 0 {
+			return "", fmt.Errorf("%w: %q is not a ULID", ErrInvalidLogID, id)
+		}
+	}
+	return id, nil
+}
+
+// ParseString is a [LogIDParser] that accepts any opaque string, such as a W3C traceparent value.
+//
+// Validation:
+//   - The length must be between 1 and 128 characters.
+//   - The allowed characters are letters, digits and - _ . : / + =
+func ParseString(id string) (string, error) {
+	if id == "" || len(id) > maxLogIDLength {
+		return "", fmt.Errorf("%w: length must be between 1 and %d", ErrInvalidLogID, maxLogIDLength)
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || strings.IndexByte("-_.:/+=", c) >= 0) {
+			return "", fmt.Errorf("%w: invalid character %q", ErrInvalidLogID, c)
+		}
+	}
+	return id, nil
+}
@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateUUIDv4(t *testing.T) {
+	got := GenerateUUIDv4()
+	if _, err := ParseUUID(got); err != nil || got[14] != '4' {
+		t.Errorf("GenerateUUIDv4() = %v, want UUID v4", got)
+	}
+}
+
+func TestGenerateUUIDv7(t *testing.T) {
+	got := GenerateUUIDv7()
+	if _, err := ParseUUID(got); err != nil || got[14] != '7' {
+		t.Errorf("GenerateUUIDv7() = %v, want UUID v7", got)
+	}
+}
+
+func TestGenerateULID(t *testing.T) {
+	got := GenerateULID()
+	if _, err := ParseULID(got); err != nil {
+		t.Errorf("GenerateULID() = %v, want ULID: %v", got, err)
+	}
+
+	next := GenerateULID()
+	if next[:10] < got[:10] {
+		t.Errorf("GenerateULID() = %v, want time ordered after %v", next, got)
+	}
+	if next == got {
+		t.Errorf("GenerateULID() = %v, want unique", next)
+	}
+}
+
+func TestParseUUID(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    string
+		want    string
+		wantErr error
+	}{
+		{name: "valid", args: "11111111-1111-1111-1111-111111111111", want: "11111111-1111-1111-1111-111111111111"},
+		{name: "upper case", args: "AAAAAAAA-1111-1111-1111-111111111111", want: "aaaaaaaa-1111-1111-1111-111111111111"},
+		{name: "nil", args: "00000000-0000-0000-0000-000000000000", wantErr: ErrInvalidLogID},
+		{name: "invalid", args: "invalid", wantErr: ErrInvalidLogID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUUID(tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseUUID() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseUUID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseULID(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    string
+		want    string
+		wantErr error
+	}{
+		{name: "valid", args: "01ARZ3NDEKTSV4RRFFQ69G5FAV", want: "01ARZ3NDEKTSV4RRFFQ69G5FAV"},
+		{name: "lower case", args: "01arz3ndektsv4rrffq69g5fav", want: "01ARZ3NDEKTSV4RRFFQ69G5FAV"},
+		{name: "overflow", args: "81ARZ3NDEKTSV4RRFFQ69G5FAV", wantErr: ErrInvalidLogID},
+		{name: "invalid character", args: "01ARZ3NDEKTSV4RRFFQ69G5FAU", wantErr: ErrInvalidLogID},
+		{name: "invalid length", args: "01ARZ3NDEK", wantErr: ErrInvalidLogID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseULID(tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseULID() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseULID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseString(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    string
+		wantErr error
+	}{
+		{name: "traceparent", args: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+		{name: "opaque", args: "job:import/2024_01.1+a=b"},
+		{name: "empty", args: "", wantErr: ErrInvalidLogID},
+		{name: "too long", args: strings.Repeat("a", maxLogIDLength+1), wantErr: ErrInvalidLogID},
+		{name: "invalid character", args: "a\nb", wantErr: ErrInvalidLogID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseString(tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseString() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.args {
+				t.Errorf("ParseString() = %v, want %v", got, tt.args)
+			}
+		})
+	}
+}
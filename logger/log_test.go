@@ -0,0 +1,173 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setDefault(t *testing.T, opts ...Option) *bytes.Buffer {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	previous := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	opts = append([]Option{WithHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{ReplaceAttr: ReplaceLevelNames}))}, opts...)
+	slog.SetDefault(NewLogger(opts...))
+	return buf
+}
+
+func TestTrace(t *testing.T) {
+	tests := []struct {
+		name     string
+		minLevel slog.Level
+		want     bool
+	}{
+		{name: "enabled", minLevel: LevelTrace, want: true},
+		{name: "disabled", minLevel: slog.LevelDebug, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := setDefault(t, WithMinLevel(tt.minLevel))
+
+			Trace(context.Background(), "payload", "size", 10)
+
+			if got := buf.Len() > 0; got != tt.want {
+				t.Fatalf("Trace() logged = %v, want %v", got, tt.want)
+			}
+			if !tt.want {
+				return
+			}
+
+			var entry struct {
+				Level  string
+				Msg    string
+				Size   int
+				Source struct {
+					File string
+				}
+			}
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatal(err)
+			}
+			if entry.Level != "TRACE" || entry.Msg != "payload" || entry.Size != 10 {
+				t.Errorf("Trace() = %s", buf.String())
+			}
+			if got := filepath.Base(entry.Source.File); got != "log_test.go" {
+				t.Errorf("Trace() source file = %v, want %v", got, "log_test.go")
+			}
+		})
+	}
+}
+
+func TestFatal(t *testing.T) {
+	var gotCode int
+	osExit = func(code int) { gotCode = code }
+	defer func() { osExit = os.Exit }()
+
+	var buf bytes.Buffer
+	ah := NewAsyncHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: levelAll, ReplaceAttr: ReplaceLevelNames}))
+	defer ah.Close(context.Background())
+
+	previous := slog.Default()
+	defer slog.SetDefault(previous)
+	slog.SetDefault(NewLogger(
+		WithHandler(ah),
+		WithMaxLevelAddSource(levelAll),
+		WithDebugOnError(10),
+	))
+
+	ctx, _ := LogIdWith(context.Background(), "", func() string { return "1" })
+	ctx = LogBuffer(ctx)
+	slog.DebugContext(ctx, "loading config")
+	Fatal(ctx, "config not found")
+
+	if gotCode != 1 {
+		t.Errorf("Fatal() exit code = %v, want %v", gotCode, 1)
+	}
+
+	want := "level=DEBUG msg=\"loading config\" log.id=1\n" +
+		"level=FATAL msg=\"config not found\" log.id=1\n"
+	var got strings.Builder
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		if _, rest, ok := strings.Cut(line, " "); ok {
+			got.WriteString(rest)
+		}
+	}
+	if got.String() != want {
+		t.Errorf("Fatal() = %q, want %q", got.String(), want)
+	}
+}
+
+type flushHandler struct {
+	slog.Handler
+	err error
+}
+
+func (fh *flushHandler) Flush(ctx context.Context) error {
+	return fh.err
+}
+
+func Test_loggerHandler_Flush(t *testing.T) {
+	errFlush := errors.New("flush")
+
+	tests := []struct {
+		name    string
+		handler slog.Handler
+		wantErr error
+	}{
+		{name: "without flush", handler: slog.Default().Handler()},
+		{name: "flush", handler: &flushHandler{err: errFlush}, wantErr: errFlush},
+		{
+			name: "sinks",
+			handler: NewMultiHandler(
+				NewSink(slog.Default().Handler()),
+				NewSink(&flushHandler{}),
+				NewSink(&flushHandler{err: errFlush}),
+			),
+			wantErr: errFlush,
+		},
+		{name: "sinks without error", handler: NewMultiHandler(NewSink(&flushHandler{}))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(WithHandler(tt.handler)).(flusher)
+			if err := h.Flush(context.Background()); !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("loggerHandler.Flush() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+type closeHandler struct {
+	slog.Handler
+	closed bool
+}
+
+func (ch *closeHandler) Close(ctx context.Context) error {
+	ch.closed = true
+	return nil
+}
+
+func Test_loggerHandler_Close(t *testing.T) {
+	ch := &closeHandler{Handler: slog.Default().Handler()}
+	h := NewHandler(WithHandler(ch)).(closer)
+	if err := h.Close(context.Background()); err != nil {
+		t.Fatalf("loggerHandler.Close() error = %v", err)
+	}
+	if !ch.closed {
+		t.Errorf("loggerHandler.Close() did not close the handler")
+	}
+
+	h = NewHandler(WithHandler(slog.Default().Handler())).(closer)
+	if err := h.Close(context.Background()); err != nil {
+		t.Errorf("loggerHandler.Close() error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,70 @@
+package logtest
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/telmoandrade/go-library/logger"
+)
+
+// SetDefault returns a new [Handler] and routes [slog.Default] to it for the duration of the test,
+// restoring the previous default logger when the test finishes.
+// A variadic set of [logger.Option] used to configure the [logger.NewHandler] placed before the recording handler.
+//
+// Important Note:
+//   - The default logger is global, tests using SetDefault must not run in parallel.
+func SetDefault(t testing.TB, opts ...logger.Option) *Handler {
+	t.Helper()
+
+	h := NewHandler()
+	previous := slog.Default()
+	slog.SetDefault(logger.NewLogger(append(opts, logger.WithHandler(h))...))
+	t.Cleanup(func() {
+		slog.SetDefault(previous)
+	})
+
+	return h
+}
+
+func records(h *Handler) string {
+	var sb strings.Builder
+	for _, r := range h.Records() {
+		sb.WriteString("\n\t")
+		sb.WriteString(r.String())
+	}
+	if sb.Len() == 0 {
+		return " none"
+	}
+	return sb.String()
+}
+
+// AssertLogged reports an error if no recorded log entry matches every predicate, and returns the first match.
+func AssertLogged(t testing.TB, h *Handler, predicates ...Predicate) Record {
+	t.Helper()
+
+	matches := h.Filter(predicates...)
+	if len(matches) == 0 {
+		t.Errorf("logtest: no log entry matches, recorded:%s", records(h))
+		return Record{}
+	}
+	return matches[0]
+}
+
+// AssertNotLogged reports an error if any recorded log entry matches every predicate.
+func AssertNotLogged(t testing.TB, h *Handler, predicates ...Predicate) {
+	t.Helper()
+
+	if matches := h.Filter(predicates...); len(matches) > 0 {
+		t.Errorf("logtest: unexpected log entry %s", matches[0])
+	}
+}
+
+// AssertCount reports an error if the number of recorded log entries matching every predicate is not the count.
+func AssertCount(t testing.TB, h *Handler, count int, predicates ...Predicate) {
+	t.Helper()
+
+	if got := len(h.Filter(predicates...)); got != count {
+		t.Errorf("logtest: log entries = %d, want %d, recorded:%s", got, count, records(h))
+	}
+}
@@ -0,0 +1,69 @@
+package logtest
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/telmoandrade/go-library/logger"
+)
+
+type fakeT struct {
+	testing.TB
+	errors []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func TestSetDefault(t *testing.T) {
+	previous := slog.Default()
+
+	t.Run("set default", func(t *testing.T) {
+		h := SetDefault(t, logger.WithMinLevel(slog.LevelDebug))
+		slog.Debug("message", "a", 1)
+
+		r := AssertLogged(t, h, Level(slog.LevelDebug), Message("message"), Attr("a", 1))
+		if _, ok := r.Attr("source.function"); !ok {
+			t.Errorf("Record.Attr() source.function not found")
+		}
+	})
+
+	if slog.Default() != previous {
+		t.Errorf("slog.Default() was not restored")
+	}
+}
+
+func TestAssert(t *testing.T) {
+	h := NewHandler()
+	l := slog.New(h)
+	l.Info("a")
+	l.Info("a")
+	l.Error("b")
+
+	tests := []struct {
+		name       string
+		assert     func(tb testing.TB)
+		wantErrors int
+	}{
+		{name: "logged", assert: func(tb testing.TB) { AssertLogged(tb, h, Message("a")) }},
+		{name: "not logged", assert: func(tb testing.TB) { AssertLogged(tb, h, Message("c")) }, wantErrors: 1},
+		{name: "assert not logged", assert: func(tb testing.TB) { AssertNotLogged(tb, h, Message("c")) }},
+		{name: "assert not logged fails", assert: func(tb testing.TB) { AssertNotLogged(tb, h, Level(slog.LevelError)) }, wantErrors: 1},
+		{name: "count", assert: func(tb testing.TB) { AssertCount(tb, h, 2, Message("a")) }},
+		{name: "count fails", assert: func(tb testing.TB) { AssertCount(tb, h, 1, Message("a")) }, wantErrors: 1},
+		{name: "count empty", assert: func(tb testing.TB) { AssertCount(tb, NewHandler(), 1) }, wantErrors: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeT{TB: t}
+			tt.assert(f)
+			if len(f.errors) != tt.wantErrors {
+				t.Errorf("errors = %v, want %v", f.errors, tt.wantErrors)
+			}
+		})
+	}
+}
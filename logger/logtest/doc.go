@@ -0,0 +1,8 @@
+// Package logtest provides an in-memory [slog.Handler] that records the log entries, with query and assertion helpers for tests.
+//
+// # Key Features:
+//   - Concurrency-safe recording handler [NewHandler], keeping the attributes and groups added with [slog.Logger.With] and [slog.Logger.WithGroup].
+//   - Query helpers by level, message and attribute path, such as request.method [Handler.Filter].
+//   - Assertion helpers for [testing.T] [AssertLogged], [AssertNotLogged] and [AssertCount].
+//   - Routing [slog.Default] to the recording handler for the duration of a test [SetDefault].
+package logtest
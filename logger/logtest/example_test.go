@@ -0,0 +1,19 @@
+package logtest_test
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/telmoandrade/go-library/logger/logtest"
+)
+
+func ExampleNewHandler() {
+	h := logtest.NewHandler()
+	l := slog.New(h)
+
+	l.Error("HTTP Response 500", slog.Group("request", slog.String("method", "GET")))
+
+	records := h.Filter(logtest.Level(slog.LevelError), logtest.Attr("request.method", "GET"))
+	fmt.Println(len(records), records[0].Message)
+	// Output: 1 HTTP Response 500
+}
@@ -0,0 +1,178 @@
+package logtest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// Handler is a [slog.Handler] that records the log entries in memory.
+	// It is created with [NewHandler].
+	Handler struct {
+		store  *store
+		groups []groupOrAttrs
+	}
+
+	store struct {
+		mu      sync.Mutex
+		records []Record
+	}
+
+	groupOrAttrs struct {
+		group string
+		attrs []slog.Attr
+	}
+
+	// Record is a log entry recorded by the [Handler].
+	Record struct {
+		Time    time.Time
+		Level   slog.Level
+		Message string
+		// Attrs are the attributes of the log entry, including the attributes added to the handler, nested in their groups.
+		Attrs []slog.Attr
+	}
+)
+
+var _ slog.Handler = &Handler{}
+
+// NewHandler returns a new [Handler] that records every log entry, at any level.
+// It is safe to be used from multiple goroutines.
+func NewHandler() *Handler {
+	return &Handler{
+		store: &store{},
+	}
+}
+
+func (h *Handler) Enabled(ctx context.Context, l slog.Level) bool {
+	return true
+}
+
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+
+	for i := len(h.groups) - 1; i >= 0; i-- {
+		goa := h.groups[i]
+		if goa.group != "" {
+			attrs = []slog.Attr{{Key: goa.group, Value: slog.GroupValue(attrs...)}}
+		} else {
+			attrs = append(slices.Clip(goa.attrs), attrs...)
+		}
+	}
+
+	h.store.mu.Lock()
+	defer h.store.mu.Unlock()
+	h.store.records = append(h.store.records, Record{
+		Time:    r.Time,
+		Level:   r.Level,
+		Message: r.Message,
+		Attrs:   attrs,
+	})
+	return nil
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return &Handler{
+		store:  h.store,
+		groups: append(slices.Clip(h.groups), groupOrAttrs{attrs: attrs}),
+	}
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &Handler{
+		store:  h.store,
+		groups: append(slices.Clip(h.groups), groupOrAttrs{group: name}),
+	}
+}
+
+// Records returns a copy of the recorded log entries, in the order they were logged.
+func (h *Handler) Records() []Record {
+	h.store.mu.Lock()
+	defer h.store.mu.Unlock()
+	return slices.Clone(h.store.records)
+}
+
+// Reset discards the recorded log entries.
+func (h *Handler) Reset() {
+	h.store.mu.Lock()
+	defer h.store.mu.Unlock()
+	h.store.records = nil
+}
+
+// Filter returns the recorded log entries matching every predicate.
+//
+// Example:
+//
+//	h.Filter(logtest.Level(slog.LevelError), logtest.Attr("request.method", "GET"))
+func (h *Handler) Filter(predicates ...Predicate) []Record {
+	var records []Record
+	for _, r := range h.Records() {
+		if r.Match(predicates...) {
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+// Attr returns the value of the attribute in the path, the groups are separated by dots, like request.method.
+func (r Record) Attr(path string) (slog.Value, bool) {
+	attrs := r.Attrs
+	keys := strings.Split(path, ".")
+	for i, key := range keys {
+		found := false
+		for _, a := range attrs {
+			if a.Key != key {
+				continue
+			}
+
+			v := a.Value.Resolve()
+			if i == len(keys)-1 {
+				return v, true
+			}
+			if v.Kind() == slog.KindGroup {
+				attrs = v.Group()
+				found = true
+				break
+			}
+		}
+		if !found {
+			return slog.Value{}, false
+		}
+	}
+	return slog.Value{}, false
+}
+
+// Match reports whether the log entry matches every predicate.
+func (r Record) Match(predicates ...Predicate) bool {
+	for _, p := range predicates {
+		if p != nil && !p(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the log entry in the text format, used in the assertion messages.
+func (r Record) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "level=%s msg=%q", r.Level, r.Message)
+	for _, a := range r.Attrs {
+		sb.WriteByte(' ')
+		sb.WriteString(a.String())
+	}
+	return sb.String()
+}
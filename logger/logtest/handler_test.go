@@ -0,0 +1,77 @@
+package logtest
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	h := NewHandler()
+	l := slog.New(h)
+
+	if !h.Enabled(context.Background(), slog.LevelDebug-4) {
+		t.Errorf("Handler.Enabled() = false, want true")
+	}
+
+	if h.WithAttrs(nil) != slog.Handler(h) || h.WithGroup("") != slog.Handler(h) {
+		t.Errorf("Handler.WithAttrs() and Handler.WithGroup() must return the same handler")
+	}
+
+	l.With("service", "billing").WithGroup("request").With("method", "GET").Info("message", "path", "/")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.Debug("concurrent")
+		}()
+	}
+	wg.Wait()
+
+	records := h.Records()
+	if len(records) != 11 {
+		t.Fatalf("Handler.Records() = %v, want %v", len(records), 11)
+	}
+
+	want := `level=INFO msg="message" service=billing request=[method=GET path=/]`
+	if got := records[0].String(); got != want {
+		t.Errorf("Record.String() = %v, want %v", got, want)
+	}
+
+	h.Reset()
+	if got := len(h.Records()); got != 0 {
+		t.Errorf("Handler.Records() = %v, want %v", got, 0)
+	}
+}
+
+func TestRecord_Attr(t *testing.T) {
+	r := Record{Attrs: []slog.Attr{
+		slog.String("a", "1"),
+		slog.Group("request", slog.String("method", "GET"), slog.Group("user", slog.Int("id", 1))),
+	}}
+
+	tests := []struct {
+		name   string
+		args   string
+		want   string
+		wantOk bool
+	}{
+		{name: "top level", args: "a", want: "1", wantOk: true},
+		{name: "group", args: "request.method", want: "GET", wantOk: true},
+		{name: "nested group", args: "request.user.id", want: "1", wantOk: true},
+		{name: "group value", args: "request.user", want: "[id=1]", wantOk: true},
+		{name: "missing", args: "request.path"},
+		{name: "not a group", args: "a.b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := r.Attr(tt.args)
+			if ok != tt.wantOk || (ok && got.String() != tt.want) {
+				t.Errorf("Record.Attr() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package logtest
+
+import (
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// Predicate reports whether a recorded log entry matches a condition.
+// It is used as a parameter for the [Handler.Filter] and the assertion helpers.
+type Predicate func(r Record) bool
+
+// Level returns a [Predicate] that matches the log entries at the level.
+func Level(level slog.Level) Predicate {
+	return func(r Record) bool {
+		return r.Level == level
+	}
+}
+
+// MinLevel returns a [Predicate] that matches the log entries at or above the level.
+func MinLevel(level slog.Level) Predicate {
+	return func(r Record) bool {
+		return r.Level >= level
+	}
+}
+
+// Message returns a [Predicate] that matches the log entries with the message.
+func Message(msg string) Predicate {
+	return func(r Record) bool {
+		return r.Message == msg
+	}
+}
+
+// MessageContains returns a [Predicate] that matches the log entries whose message contains the text.
+func MessageContains(text string) Predicate {
+	return func(r Record) bool {
+		return strings.Contains(r.Message, text)
+	}
+}
+
+// HasAttr returns a [Predicate] that matches the log entries with the attribute in the path, like request.method.
+func HasAttr(path string) Predicate {
+	return func(r Record) bool {
+		_, ok := r.Attr(path)
+		return ok
+	}
+}
+
+// Attr returns a [Predicate] that matches the log entries with the attribute in the path, like request.method, equal to the value.
+// Numbers are compared by their kind, so an int value matches an attribute created with [slog.Int].
+func Attr(path string, value any) Predicate {
+	want := slog.AnyValue(value)
+	return func(r Record) bool {
+		got, ok := r.Attr(path)
+		if !ok {
+			return false
+		}
+		if got.Kind() == slog.KindAny || want.Kind() == slog.KindAny {
+			return reflect.DeepEqual(got.Any(), want.Any())
+		}
+		return got.Equal(want)
+	}
+}
@@ -0,0 +1,51 @@
+package logtest
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+func TestPredicate(t *testing.T) {
+	err := errors.New("failed")
+	r := Record{
+		Level:   slog.LevelWarn,
+		Message: "HTTP Response 404",
+		Attrs: []slog.Attr{
+			slog.Group("response", slog.Int("status", 404)),
+			slog.Any("error", err),
+			slog.Any("tags", []string{"a"}),
+		},
+	}
+
+	tests := []struct {
+		name string
+		args []Predicate
+		want bool
+	}{
+		{name: "without predicates", want: true},
+		{name: "nil predicate", args: []Predicate{nil}, want: true},
+		{name: "level", args: []Predicate{Level(slog.LevelWarn)}, want: true},
+		{name: "other level", args: []Predicate{Level(slog.LevelInfo)}},
+		{name: "min level", args: []Predicate{MinLevel(slog.LevelInfo)}, want: true},
+		{name: "above min level", args: []Predicate{MinLevel(slog.LevelError)}},
+		{name: "message", args: []Predicate{Message("HTTP Response 404")}, want: true},
+		{name: "other message", args: []Predicate{Message("HTTP Response")}},
+		{name: "message contains", args: []Predicate{MessageContains("404")}, want: true},
+		{name: "has attr", args: []Predicate{HasAttr("response.status")}, want: true},
+		{name: "missing attr", args: []Predicate{HasAttr("response.size")}},
+		{name: "attr int", args: []Predicate{Attr("response.status", 404)}, want: true},
+		{name: "attr other value", args: []Predicate{Attr("response.status", 200)}},
+		{name: "attr missing", args: []Predicate{Attr("response.size", 0)}},
+		{name: "attr error", args: []Predicate{Attr("error", err)}, want: true},
+		{name: "attr slice", args: []Predicate{Attr("tags", []string{"a"})}, want: true},
+		{name: "all", args: []Predicate{Level(slog.LevelWarn), Attr("response.status", 200)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Match(tt.args...); got != tt.want {
+				t.Errorf("Record.Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
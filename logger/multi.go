@@ -0,0 +1,165 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+type (
+	// Sink is a downstream handler of the [NewMultiHandler] with its own minimum level and filter.
+	// It is created with [NewSink].
+	Sink struct {
+		handler slog.Handler
+		level   slog.Leveler
+		filter  func(context.Context, slog.Record) bool
+	}
+
+	// OptionSink is used to apply configurations to a [Sink] when creating it with [NewSink].
+	OptionSink func(*Sink)
+
+	multiHandler struct {
+		sinks []Sink
+	}
+)
+
+var _ slog.Handler = &multiHandler{}
+
+// NewSink returns a new [Sink] forwarding the records to the handler.
+// A variadic set of [OptionSink] used to configure the sink.
+//
+// Default:
+//   - Without a level, the records are filtered only by the handler itself.
+//
+// Important Note:
+//   - With a level, the records are filtered only by the sink level, replacing the level of the handler itself,
+//     so a handler created with the default [slog.LevelInfo] receives the debug records of a sink at [slog.LevelDebug].
+func NewSink(handler slog.Handler, opts ...OptionSink) Sink {
+	s := Sink{
+		handler: handler,
+	}
+
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	return s
+}
+
+// WithSinkLevel is an [OptionSink] that defines the minimum log level of the sink, a [slog.LevelVar] allows changing it at runtime.
+func WithSinkLevel(level slog.Leveler) OptionSink {
+	return func(s *Sink) {
+		s.level = level
+	}
+}
+
+// WithSinkFilter is an [OptionSink] that defines a predicate, the record is forwarded to the sink only when it returns true.
+func WithSinkFilter(filter func(ctx context.Context, r slog.Record) bool) OptionSink {
+	return func(s *Sink) {
+		s.filter = filter
+	}
+}
+
+// NewMultiHandler returns a new [slog.Handler] that forwards each record to every [Sink] that accepts it.
+//
+// Behavior:
+//   - A record is forwarded to a sink when it is at or above the sink level, or enabled by the sink handler without a sink level,
+//     and accepted by the sink filter.
+//   - Each sink receives its own copy of the record, the attributes and groups are propagated to every sink.
+//   - An error or a panic in a sink does not prevent the other sinks from receiving the record, the errors are joined.
+//
+// Important Note:
+//   - Sinks are called sequentially, a slow sink can be wrapped with [NewAsyncHandler].
+func NewMultiHandler(sinks ...Sink) slog.Handler {
+	mh := &multiHandler{
+		sinks: make([]Sink, 0, len(sinks)),
+	}
+
+	for _, s := range sinks {
+		if s.handler != nil {
+			mh.sinks = append(mh.sinks, s)
+		}
+	}
+
+	return mh
+}
+
+// WithSinks is an [Option] that forwards the processed log entries to every [Sink], using [NewMultiHandler].
+// It replaces the handler defined by [WithHandler].
+//
+// Important Note:
+//   - The handler minimum level is applied before the sinks, it must be at or below the lowest sink level.
+func WithSinks(sinks ...Sink) Option {
+	return func(lh *loggerHandler) {
+		lh.handler = NewMultiHandler(sinks...)
+	}
+}
+
+func (s Sink) enabled(ctx context.Context, l slog.Level) bool {
+	if s.level != nil {
+		return l >= s.level.Level()
+	}
+	return s.handler.Enabled(ctx, l)
+}
+
+func (s Sink) handle(ctx context.Context, r slog.Record) (err error) {
+	defer func() {
+		if rvr := recover(); rvr != nil {
+			err = fmt.Errorf("logger: sink panic: %v", rvr)
+		}
+	}()
+
+	if s.filter != nil && !s.filter(ctx, r) {
+		return nil
+	}
+	return s.handler.Handle(ctx, r.Clone())
+}
+
+func (mh *multiHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	for _, s := range mh.sinks {
+		if s.enabled(ctx, l) {
+			return true
+		}
+	}
+	return false
+}
+
+func (mh *multiHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, s := range mh.sinks {
+		if !s.enabled(ctx, r.Level) {
+			continue
+		}
+		if err := s.handle(ctx, r); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (mh *multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return mh
+	}
+
+	c := &multiHandler{sinks: make([]Sink, len(mh.sinks))}
+	for i, s := range mh.sinks {
+		s.handler = s.handler.WithAttrs(attrs)
+		c.sinks[i] = s
+	}
+	return c
+}
+
+func (mh *multiHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return mh
+	}
+
+	c := &multiHandler{sinks: make([]Sink, len(mh.sinks))}
+	for i, s := range mh.sinks {
+		s.handler = s.handler.WithGroup(name)
+		c.sinks[i] = s
+	}
+	return c
+}
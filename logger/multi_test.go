@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+type failingHandler struct {
+	slog.Handler
+	err   error
+	panic bool
+}
+
+func (fh *failingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if fh.panic {
+		panic("failing handler")
+	}
+	return fh.err
+}
+
+func Test_multiHandler_Enabled(t *testing.T) {
+	var buf bytes.Buffer
+	h := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})
+
+	tests := []struct {
+		name  string
+		sinks []Sink
+		args  slog.Level
+		want  bool
+	}{
+		{name: "without sinks", args: slog.LevelError},
+		{name: "nil handler", sinks: []Sink{NewSink(nil)}, args: slog.LevelError},
+		{name: "handler level", sinks: []Sink{NewSink(h)}, args: slog.LevelInfo},
+		{name: "sink level", sinks: []Sink{NewSink(h, WithSinkLevel(slog.LevelError))}, args: slog.LevelWarn},
+		{name: "sink level below handler level", sinks: []Sink{NewSink(h, WithSinkLevel(slog.LevelDebug))}, args: slog.LevelInfo, want: true},
+		{name: "any sink", sinks: []Sink{NewSink(h, WithSinkLevel(slog.LevelError)), NewSink(h)}, args: slog.LevelWarn, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewMultiHandler(tt.sinks...).Enabled(context.Background(), tt.args); got != tt.want {
+				t.Errorf("multiHandler.Enabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithSinks(t *testing.T) {
+	var stdout, otel, errFile bytes.Buffer
+	replaceAttr := func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) == 0 && a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}
+
+	failing := &failingHandler{Handler: slog.Default().Handler(), err: errors.New("sink error")}
+	panicking := &failingHandler{Handler: slog.Default().Handler(), panic: true}
+
+	l := NewLogger(
+		WithMinLevel(slog.LevelDebug),
+		WithMaxLevelAddSource(slog.LevelDebug-1),
+		WithSinks(
+			NewSink(failing),
+			NewSink(slog.NewJSONHandler(&stdout, &slog.HandlerOptions{ReplaceAttr: replaceAttr}), WithSinkLevel(slog.LevelInfo)),
+			NewSink(panicking),
+			NewSink(slog.NewTextHandler(&otel, &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: replaceAttr}),
+				WithSinkFilter(func(ctx context.Context, r slog.Record) bool {
+					return !strings.HasPrefix(r.Message, "private")
+				}),
+			),
+			NewSink(slog.NewTextHandler(&errFile, &slog.HandlerOptions{ReplaceAttr: replaceAttr}), WithSinkLevel(slog.LevelError)),
+		),
+	)
+
+	l = l.With("service", "billing").WithGroup("g")
+	l.Debug("debug", "a", 1)
+	l.Info("private info")
+	l.Error("error", "a", 2)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{
+			name: "stdout",
+			got:  stdout.String(),
+			want: `{"level":"INFO","msg":"private info","service":"billing"}` + "\n" +
+				`{"level":"ERROR","msg":"error","service":"billing","g":{"a":2}}` + "\n",
+		},
+		{
+			name: "otel",
+			got:  otel.String(),
+			want: "level=DEBUG msg=debug service=billing g.a=1\nlevel=ERROR msg=error service=billing g.a=2\n",
+		},
+		{
+			name: "error file",
+			got:  errFile.String(),
+			want: "level=ERROR msg=error service=billing g.a=2\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("output = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSink_levelBelowHandler(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(NewMultiHandler(
+		NewSink(slog.NewJSONHandler(&buf, nil), WithSinkLevel(slog.LevelDebug)),
+	))
+
+	l.Debug("debug")
+	if got := buf.String(); !strings.Contains(got, `"msg":"debug"`) {
+		t.Errorf("output = %v, want debug record", got)
+	}
+}
+
+func Test_multiHandler_Handle_errors(t *testing.T) {
+	var buf bytes.Buffer
+	sinkErr := errors.New("sink error")
+
+	mh := NewMultiHandler(
+		NewSink(&failingHandler{Handler: slog.Default().Handler(), err: sinkErr}),
+		NewSink(&failingHandler{Handler: slog.Default().Handler(), panic: true}),
+		NewSink(slog.NewTextHandler(&buf, nil)),
+	)
+
+	err := mh.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "message", 0))
+	if !errors.Is(err, sinkErr) || !strings.Contains(err.Error(), "logger: sink panic: failing handler") {
+		t.Errorf("multiHandler.Handle() error = %v, want sink error and sink panic", err)
+	}
+	if got := buf.String(); got != "level=INFO msg=message\n" {
+		t.Errorf("output = %v, want %v", got, "level=INFO msg=message\n")
+	}
+}
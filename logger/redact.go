@@ -0,0 +1,286 @@
+package logger
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+type (
+	// RedactMode defines how the sensitive values are masked by [WithRedaction].
+	RedactMode int
+
+	redactor struct {
+		keys     []string
+		keyWords [][]string
+		types    []reflect.Type
+		patterns []*regexp.Regexp
+		mode     RedactMode
+		hashKey  []byte
+	}
+
+	// OptionRedact is used to apply configurations to the redaction stage when creating it with [WithRedaction].
+	OptionRedact func(*redactor)
+)
+
+const (
+	// RedactFull replaces the whole value with [REDACTED].
+	RedactFull RedactMode = iota
+	// RedactPartial keeps the last 4 characters of values with at least 8 characters, like ****1111.
+	RedactPartial
+	// RedactHash replaces the value with a keyed hash HMAC-SHA256, like hash:5d41402abc4b2a76,
+	// allowing equal values to be correlated without exposing them.
+	RedactHash
+)
+
+var (
+	// RedactPatternEmail matches email addresses.
+	RedactPatternEmail = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// RedactPatternCardNumber matches payment card numbers, validated by the Luhn algorithm.
+	RedactPatternCardNumber = regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`)
+	// RedactPatternJWT matches JSON Web Tokens.
+	RedactPatternJWT = regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)
+)
+
+// WithRedaction is an [Option] that masks sensitive data before the log entry is forwarded to the handler.
+// A variadic set of [OptionRedact] used to configure the redaction.
+//
+// Default Behavior:
+//   - Attributes whose key ends with the words password, passwd, secret, authorization, token, cookie, apikey or api_key,
+//     case insensitive, are masked. Like access_token and X-Auth-Token, but not token_count.
+//   - Strings matching [RedactPatternEmail], [RedactPatternCardNumber] and [RedactPatternJWT] are masked inside the values and the message.
+//   - The patterns are applied to string values, errors and [fmt.Stringer] values, other values are not formatted.
+//     Struct, map and slice values of [slog.KindAny] attributes are not scrubbed, they can implement [slog.LogValuer]
+//     or be masked by type with [WithRedactTypes].
+//   - The mode is [RedactFull].
+//
+// Redaction is applied to the record attributes, the attributes added with [slog.Logger.With] and [AppendCtx],
+// including attributes inside groups and values resolved from [slog.LogValuer].
+func WithRedaction(opts ...OptionRedact) Option {
+	return func(lh *loggerHandler) {
+		rd := &redactor{
+			keys:     []string{"password", "passwd", "secret", "authorization", "token", "cookie", "apikey", "api_key"},
+			patterns: []*regexp.Regexp{RedactPatternEmail, RedactPatternCardNumber, RedactPatternJWT},
+		}
+
+		for _, opt := range opts {
+			opt(rd)
+		}
+
+		for _, key := range rd.keys {
+			rd.keyWords = append(rd.keyWords, keyWords(key))
+		}
+
+		lh.redactor = rd
+	}
+}
+
+// WithRedactKeys is an [OptionRedact] that adds keys to the list of sensitive attribute keys.
+// An attribute is masked when its key ends with the words of any of the keys, case insensitive.
+// The words are separated by any character other than letters and digits, and by the case changes, like userToken.
+func WithRedactKeys(keys ...string) OptionRedact {
+	return func(rd *redactor) {
+		for _, key := range keys {
+			key = strings.ToLower(strings.TrimSpace(key))
+			if key != "" && !slices.Contains(rd.keys, key) {
+				rd.keys = append(rd.keys, key)
+			}
+		}
+	}
+}
+
+// WithRedactTypes is an [OptionRedact] that masks every attribute whose value has the same type as one of the values.
+//
+// Example:
+//
+//	logger.WithRedaction(logger.WithRedactTypes(Credentials{}, &Credentials{}))
+func WithRedactTypes(values ...any) OptionRedact {
+	return func(rd *redactor) {
+		for _, v := range values {
+			if t := reflect.TypeOf(v); t != nil && !slices.Contains(rd.types, t) {
+				rd.types = append(rd.types, t)
+			}
+		}
+	}
+}
+
+// WithRedactPatterns is an [OptionRedact] that defines the patterns masked inside string values and the message,
+// replacing the default patterns.
+func WithRedactPatterns(patterns ...*regexp.Regexp) OptionRedact {
+	return func(rd *redactor) {
+		rd.patterns = slices.DeleteFunc(slices.Clone(patterns), func(p *regexp.Regexp) bool {
+			return p == nil
+		})
+	}
+}
+
+// WithRedactMode is an [OptionRedact] that defines the [RedactMode], the key is used by [RedactHash].
+//
+// Important Note:
+//   - If the mode is [RedactHash] and the key is empty, it will cause a panic.
+//     A hash without a secret key can be reversed by hashing the guessed values.
+func WithRedactMode(mode RedactMode, key []byte) OptionRedact {
+	if mode == RedactHash && len(key) == 0 {
+		panic(errors.New("logger: RedactHash requires a non-empty key"))
+	}
+
+	return func(rd *redactor) {
+		rd.mode = mode
+		rd.hashKey = key
+	}
+}
+
+func luhnValid(number string) bool {
+	sum := 0
+	digits := 0
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if digits%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+	}
+	return digits > 0 && sum%10 == 0
+}
+
+func (rd *redactor) mask(s string) string {
+	switch rd.mode {
+	case RedactPartial:
+		if len(s) >= 8 {
+			return "****" + s[len(s)-4:]
+		}
+		return "****"
+	case RedactHash:
+		mac := hmac.New(sha256.New, rd.hashKey)
+		mac.Write([]byte(s))
+		return "hash:" + hex.EncodeToString(mac.Sum(nil))[:16]
+	}
+	return "[REDACTED]"
+}
+
+func (rd *redactor) scrub(s string) string {
+	for _, p := range rd.patterns {
+		s = p.ReplaceAllStringFunc(s, func(m string) string {
+			if p == RedactPatternCardNumber && !luhnValid(m) {
+				return m
+			}
+			return rd.mask(m)
+		})
+	}
+	return s
+}
+
+// keyWords returns the lower case words of the key, split by the characters other than letters and digits
+// and by the case changes, like X-Auth-Token, auth_token and authToken are auth and token.
+func keyWords(key string) []string {
+	var words []string
+	rs := []rune(key)
+	start := -1
+	for i, r := range rs {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, strings.ToLower(string(rs[start:i])))
+			}
+			start = -1
+			continue
+		}
+
+		// A new word starts at an upper case letter after a lower case letter or a digit,
+		// or at the last upper case letter of an acronym followed by a lower case letter, like APIKey.
+		if start >= 0 && unicode.IsUpper(r) {
+			prev := rs[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(rs) && unicode.IsLower(rs[i+1])) {
+				words = append(words, strings.ToLower(string(rs[start:i])))
+				start = i
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, strings.ToLower(string(rs[start:])))
+	}
+	return words
+}
+
+func (rd *redactor) sensitiveKey(key string) bool {
+	words := keyWords(key)
+	for _, kw := range rd.keyWords {
+		if len(kw) > 0 && len(kw) <= len(words) && slices.Equal(words[len(words)-len(kw):], kw) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rd *redactor) sensitiveType(v any) bool {
+	if len(rd.types) == 0 || v == nil {
+		return false
+	}
+	return slices.Contains(rd.types, reflect.TypeOf(v))
+}
+
+func (rd *redactor) attr(a slog.Attr) slog.Attr {
+	v := a.Value.Resolve()
+
+	if rd.sensitiveKey(a.Key) {
+		return slog.String(a.Key, rd.mask(v.String()))
+	}
+
+	switch v.Kind() {
+	case slog.KindGroup:
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(rd.attrs(v.Group())...)}
+	case slog.KindString:
+		return slog.String(a.Key, rd.scrub(v.String()))
+	case slog.KindAny:
+		if rd.sensitiveType(v.Any()) {
+			return slog.String(a.Key, rd.mask(fmt.Sprint(v.Any())))
+		}
+		switch v.Any().(type) {
+		case error, fmt.Stringer:
+			if s := fmt.Sprint(v.Any()); rd.scrub(s) != s {
+				return slog.String(a.Key, rd.scrub(s))
+			}
+		}
+	}
+
+	return slog.Attr{Key: a.Key, Value: v}
+}
+
+func (rd *redactor) attrs(attrs []slog.Attr) []slog.Attr {
+	result := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		result = append(result, rd.attr(a))
+	}
+	return result
+}
+
+func (rd *redactor) record(r slog.Record) slog.Record {
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, rd.attr(a))
+		return true
+	})
+
+	nr := slog.NewRecord(r.Time, r.Level, rd.scrub(r.Message), r.PC)
+	nr.AddAttrs(attrs...)
+	return nr
+}
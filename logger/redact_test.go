@@ -0,0 +1,229 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+type (
+	redactCredentials struct {
+		User string
+	}
+
+	redactValuer struct{}
+
+	redactStringer struct {
+		Email string
+	}
+
+	redactContact struct {
+		Email string
+	}
+)
+
+func (s redactStringer) String() string {
+	return "email " + s.Email
+}
+
+func (redactValuer) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("password", "123"), slog.String("email", "john@example.com"))
+}
+
+func Test_luhnValid(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want bool
+	}{
+		{name: "valid", args: "4111 1111 1111 1111", want: true},
+		{name: "valid dash", args: "5500-0000-0000-0004", want: true},
+		{name: "invalid", args: "4111 1111 1111 1112"},
+		{name: "empty", args: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := luhnValid(tt.args); got != tt.want {
+				t.Errorf("luhnValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_redactor_mask(t *testing.T) {
+	tests := []struct {
+		name string
+		mode RedactMode
+		args string
+		want string
+	}{
+		{name: "full", mode: RedactFull, args: "4111111111111111", want: "[REDACTED]"},
+		{name: "partial", mode: RedactPartial, args: "4111111111111111", want: "****1111"},
+		{name: "partial short", mode: RedactPartial, args: "1234", want: "****"},
+		{name: "hash", mode: RedactHash, args: "secret", want: "hash:25cf3c44c8f39313"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lh := &loggerHandler{}
+			WithRedaction(WithRedactMode(tt.mode, []byte("key")))(lh)
+
+			if got := lh.redactor.mask(tt.args); got != tt.want {
+				t.Errorf("redactor.mask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithRedactMode_hashEmptyKey(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("WithRedactMode() did not panic")
+		}
+	}()
+
+	WithRedactMode(RedactHash, nil)
+}
+
+func Test_keyWords(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want []string
+	}{
+		{name: "single", args: "token", want: []string{"token"}},
+		{name: "snake case", args: "access_token", want: []string{"access", "token"}},
+		{name: "header", args: "X-Auth-Token", want: []string{"x", "auth", "token"}},
+		{name: "camel case", args: "userToken", want: []string{"user", "token"}},
+		{name: "acronym", args: "APIKey", want: []string{"api", "key"}},
+		{name: "digit", args: "auth2Token", want: []string{"auth2", "token"}},
+		{name: "dotted", args: "request.token", want: []string{"request", "token"}},
+		{name: "empty", args: "", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keyWords(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("keyWords() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_redactor_sensitiveKey(t *testing.T) {
+	lh := &loggerHandler{}
+	WithRedaction(WithRedactKeys("session id"))(lh)
+
+	tests := []struct {
+		name string
+		args string
+		want bool
+	}{
+		{name: "equal", args: "token", want: true},
+		{name: "suffix", args: "access_token", want: true},
+		{name: "header", args: "X-Auth-Token", want: true},
+		{name: "camel case", args: "userPassword", want: true},
+		{name: "api key", args: "X-Api-Key", want: true},
+		{name: "custom words", args: "userSessionID", want: true},
+		{name: "prefix", args: "token_count", want: false},
+		{name: "inside word", args: "tokenizer", want: false},
+		{name: "other", args: "count", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lh.redactor.sensitiveKey(tt.args); got != tt.want {
+				t.Errorf("redactor.sensitiveKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_redactor_scrub(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []OptionRedact
+		args string
+		want string
+	}{
+		{name: "email", args: "user john.doe@example.com", want: "user [REDACTED]"},
+		{name: "card", args: "card 4111 1111 1111 1111 ok", want: "card [REDACTED] ok"},
+		{name: "not card", args: "id 4111 1111 1111 1112", want: "id 4111 1111 1111 1112"},
+		{name: "jwt", args: "/reset/eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig", want: "/reset/[REDACTED]"},
+		{
+			name: "custom pattern",
+			opts: []OptionRedact{WithRedactPatterns(regexp.MustCompile(`\d{3}-\d{2}-\d{4}`), nil)},
+			args: "ssn 123-45-6789 john@example.com",
+			want: "ssn [REDACTED] john@example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lh := &loggerHandler{}
+			WithRedaction(tt.opts...)(lh)
+
+			if got := lh.redactor.scrub(tt.args); got != tt.want {
+				t.Errorf("redactor.scrub() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(
+		WithRedaction(
+			WithRedactKeys("ssn", " ", "SSN"),
+			WithRedactTypes(redactCredentials{}, nil),
+		),
+		WithHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+					return slog.Attr{}
+				}
+				return a
+			},
+		})),
+	)
+
+	ctx := AppendCtx(context.Background(), slog.String("user_token", "abc"))
+	l.With("Authorization", "Bearer abc").WithGroup("g").With("cookie", "a=b").InfoContext(ctx, "sent to john@example.com",
+		slog.String("ssn", "123"),
+		slog.Any("credentials", redactCredentials{User: "john"}),
+		slog.Any("valuer", redactValuer{}),
+		slog.Any("error", errors.New("invalid john@example.com")),
+		slog.Int("count", 1),
+		slog.Int("token_count", 2),
+		slog.Any("stringer", redactStringer{Email: "john@example.com"}),
+		slog.Any("struct", redactContact{Email: "john@example.com"}),
+		slog.Group("request", slog.String("path", "/users/john@example.com")),
+	)
+
+	got := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+
+	want := map[string]any{
+		"msg":           "sent to [REDACTED]",
+		"Authorization": "[REDACTED]",
+		"user_token":    "[REDACTED]",
+		"g": map[string]any{
+			"cookie":      "[REDACTED]",
+			"ssn":         "[REDACTED]",
+			"credentials": "[REDACTED]",
+			"valuer":      map[string]any{"password": "[REDACTED]", "email": "[REDACTED]"},
+			"error":       "invalid [REDACTED]",
+			"count":       float64(1),
+			"token_count": float64(2),
+			"stringer":    "email [REDACTED]",
+			"struct":      map[string]any{"Email": "john@example.com"},
+			"request":     map[string]any{"path": "/users/[REDACTED]"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("log = %v, want %v", got, want)
+	}
+}
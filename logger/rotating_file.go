@@ -0,0 +1,443 @@
+package logger
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// RotatingFile is an [io.Writer] that writes to a file, rotating it by size and by time.
+	// It is used with [slog.NewJSONHandler] or [slog.NewTextHandler] in [WithHandler].
+	// It is created with [NewRotatingFile].
+	RotatingFile struct {
+		mu         sync.Mutex
+		filename   string
+		maxSize    int64
+		interval   time.Duration
+		compress   bool
+		maxBackups int
+		maxAge     time.Duration
+		signals    []os.Signal
+		now        func() time.Time
+		rename     func(oldpath, newpath string) error
+
+		file         *os.File
+		size         int64
+		rotateAt     time.Time
+		rotateFailed bool
+		closed       bool
+		notify       chan os.Signal
+		done         chan struct{}
+		maintainMu   sync.Mutex
+		wg           sync.WaitGroup
+	}
+
+	// OptionRotatingFile is used to apply configurations to a [RotatingFile] when creating it with [NewRotatingFile].
+	OptionRotatingFile func(*RotatingFile)
+)
+
+const rotatingFileTimeFormat = "2006-01-02T15-04-05.000"
+
+var _ io.Writer = &RotatingFile{}
+
+// NewRotatingFile returns a new [RotatingFile] writing to the file, creating the directory if it does not exist.
+// A variadic set of [OptionRotatingFile] used to configure the rotation.
+//
+// Behavior:
+//   - The rotated file is renamed with its rotation time, like app-2006-01-02T15-04-05.000.log.
+//   - Compression and removal of the rotated files run in the background.
+//   - If the rotation fails, the records are still written to the current file and the rotation is retried on the next write,
+//     the error is logged once until a rotation succeeds.
+//   - On the signals defined by [WithReopenSignals], like SIGHUP, the file is closed and opened again, so it can be moved by logrotate.
+//
+// Default:
+//   - Without [WithMaxSize] or [WithRotateInterval], the file is never rotated.
+//   - Rotated files are not compressed, and all of them are kept.
+//   - No signal handler is installed.
+//
+// Important Note:
+//   - [RotatingFile.Close] must be called before the application exits,
+//     the graceful package closes it on shutdown with NewGracefulCloser.
+//
+// Example:
+//
+//	rf, err := logger.NewRotatingFile("/var/log/app/app.log",
+//		logger.WithMaxSize(100<<20),
+//		logger.WithCompress(true),
+//		logger.WithMaxBackups(10),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	slog.SetDefault(logger.NewLogger(logger.WithHandler(slog.NewJSONHandler(rf, nil))))
+func NewRotatingFile(filename string, opts ...OptionRotatingFile) (*RotatingFile, error) {
+	rf := &RotatingFile{
+		filename: filename,
+		now:      time.Now,
+		rename:   os.Rename,
+		done:     make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(rf)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return nil, fmt.Errorf("logger: creating log directory: %w", err)
+	}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+
+	if len(rf.signals) > 0 {
+		rf.notify = make(chan os.Signal, 1)
+		signal.Notify(rf.notify, rf.signals...)
+		go rf.watch()
+	}
+
+	return rf, nil
+}
+
+// WithMaxSize is an [OptionRotatingFile] that rotates the file before it exceeds the size in bytes.
+func WithMaxSize(size int64) OptionRotatingFile {
+	return func(rf *RotatingFile) {
+		rf.maxSize = max(size, 0)
+	}
+}
+
+// WithRotateInterval is an [OptionRotatingFile] that rotates the file at every interval, like [time.Hour] or 24 * [time.Hour].
+// The rotation time is aligned to the interval in UTC, so a daily rotation happens at midnight UTC.
+func WithRotateInterval(interval time.Duration) OptionRotatingFile {
+	return func(rf *RotatingFile) {
+		rf.interval = max(interval, 0)
+	}
+}
+
+// WithCompress is an [OptionRotatingFile] that compresses the rotated files with gzip.
+func WithCompress(compress bool) OptionRotatingFile {
+	return func(rf *RotatingFile) {
+		rf.compress = compress
+	}
+}
+
+// WithMaxBackups is an [OptionRotatingFile] that defines the maximum number of rotated files kept, the oldest are removed.
+// Zero keeps all the rotated files.
+func WithMaxBackups(count int) OptionRotatingFile {
+	return func(rf *RotatingFile) {
+		rf.maxBackups = max(count, 0)
+	}
+}
+
+// WithMaxAge is an [OptionRotatingFile] that defines the maximum age of the rotated files kept, the older are removed.
+// Zero keeps all the rotated files.
+func WithMaxAge(age time.Duration) OptionRotatingFile {
+	return func(rf *RotatingFile) {
+		rf.maxAge = max(age, 0)
+	}
+}
+
+// WithReopenSignals is an [OptionRotatingFile] that defines the signals that close and open the file again, like SIGHUP.
+// Without signals, the file is reopened only by [RotatingFile.Reopen].
+//
+// Default:
+//   - No signal, the file is reopened only by [RotatingFile.Reopen].
+//
+// Important Note:
+//   - The signal handler is process-wide, it stops the default behavior of the signals, such as the termination on SIGHUP,
+//     and receives the signals handled by the application too.
+func WithReopenSignals(signals ...os.Signal) OptionRotatingFile {
+	return func(rf *RotatingFile) {
+		rf.signals = slices.DeleteFunc(slices.Clone(signals), func(s os.Signal) bool {
+			return s == nil
+		})
+	}
+}
+
+// Write writes to the file, rotating it first when the size or the interval is exceeded.
+// When the rotation fails, it writes to the current file and the rotation is retried on the next write.
+// It is safe to be called from multiple goroutines.
+func (rf *RotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.closed {
+		return 0, fmt.Errorf("logger: rotating file: %w", os.ErrClosed)
+	}
+
+	if (rf.maxSize > 0 && rf.size > 0 && rf.size+int64(len(p)) > rf.maxSize) ||
+		(!rf.rotateAt.IsZero() && !rf.now().Before(rf.rotateAt)) {
+		if err := rf.rotate(); err != nil {
+			rf.reportRotate(err)
+		} else {
+			rf.rotateFailed = false
+		}
+	}
+
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+// Rotate closes the file, renames it with the rotation time and opens a new file.
+func (rf *RotatingFile) Rotate() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.closed {
+		return fmt.Errorf("logger: rotating file: %w", os.ErrClosed)
+	}
+	return rf.rotate()
+}
+
+// Reopen closes the file and opens it again, it is called on the signals defined by [WithReopenSignals] after the file was moved by logrotate.
+func (rf *RotatingFile) Reopen() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.closed {
+		return fmt.Errorf("logger: rotating file: %w", os.ErrClosed)
+	}
+	if err := rf.file.Close(); err != nil {
+		return fmt.Errorf("logger: closing log file: %w", err)
+	}
+	return rf.open()
+}
+
+// Close flushes and closes the file, waiting for the compression and removal of the rotated files.
+// It returns the context error if the context is done first.
+//
+// Behavior:
+//   - Writes after the call return an error wrapping [os.ErrClosed].
+//   - Calling Close more than once is safe.
+func (rf *RotatingFile) Close(ctx context.Context) error {
+	rf.mu.Lock()
+	if rf.closed {
+		rf.mu.Unlock()
+		return nil
+	}
+	rf.closed = true
+
+	if rf.notify != nil {
+		signal.Stop(rf.notify)
+	}
+	close(rf.done)
+
+	err := errors.Join(rf.file.Sync(), rf.file.Close())
+	rf.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("logger: closing log file: %w", err)
+	}
+
+	maintained := make(chan struct{})
+	go func() {
+		rf.wg.Wait()
+		close(maintained)
+	}()
+
+	select {
+	case <-maintained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (rf *RotatingFile) watch() {
+	for {
+		select {
+		case <-rf.notify:
+			if err := rf.Reopen(); err != nil {
+				slog.Error(fmt.Sprintf("[LOGGER] Error reopening log file: %s", err.Error()))
+			}
+		case <-rf.done:
+			return
+		}
+	}
+}
+
+func (rf *RotatingFile) open() error {
+	f, err := os.OpenFile(rf.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("logger: opening log file: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("logger: opening log file: %w", err)
+	}
+
+	rf.file = f
+	rf.size = info.Size()
+	if rf.interval > 0 {
+		rf.rotateAt = rf.now().Truncate(rf.interval).Add(rf.interval)
+	}
+	return nil
+}
+
+func (rf *RotatingFile) rotate() error {
+	if err := rf.file.Close(); err != nil {
+		return fmt.Errorf("logger: closing log file: %w", err)
+	}
+
+	if err := rf.rename(rf.filename, rf.backupName(rf.now())); err != nil {
+		// The current file is opened again, keeping the rotation time so the rotation is retried on the next write.
+		rotateAt := rf.rotateAt
+		if openErr := rf.open(); openErr != nil {
+			return errors.Join(fmt.Errorf("logger: renaming log file: %w", err), openErr)
+		}
+		rf.rotateAt = rotateAt
+		return fmt.Errorf("logger: renaming log file: %w", err)
+	}
+
+	if err := rf.open(); err != nil {
+		return err
+	}
+
+	rf.wg.Add(1)
+	go func() {
+		defer rf.wg.Done()
+		rf.maintain()
+	}()
+	return nil
+}
+
+// reportRotate logs the rotation error once until a rotation succeeds.
+// It is logged in another goroutine, because the handler writing to the file may hold its own lock.
+func (rf *RotatingFile) reportRotate(err error) {
+	if rf.rotateFailed {
+		return
+	}
+	rf.rotateFailed = true
+	go slog.Error(fmt.Sprintf("[LOGGER] Error rotating log file: %s", err.Error()))
+}
+
+// backupName returns the name of the rotated file, the time is moved forward when a rotated file with the same name exists.
+func (rf *RotatingFile) backupName(t time.Time) string {
+	dir, base := filepath.Split(rf.filename)
+	ext := filepath.Ext(base)
+
+	for {
+		name := filepath.Join(dir, fmt.Sprintf("%s-%s%s", strings.TrimSuffix(base, ext), t.Format(rotatingFileTimeFormat), ext))
+		if _, err := os.Stat(name); errors.Is(err, os.ErrNotExist) {
+			if _, err := os.Stat(name + ".gz"); errors.Is(err, os.ErrNotExist) {
+				return name
+			}
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+type rotatedFile struct {
+	path string
+	time time.Time
+}
+
+// backups returns the rotated files, the newest first.
+func (rf *RotatingFile) backups() ([]rotatedFile, error) {
+	dir, base := filepath.Split(rf.filename)
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, ext) + "-"
+
+	entries, err := os.ReadDir(filepath.Clean(dir))
+	if err != nil {
+		return nil, err
+	}
+
+	files := []rotatedFile{}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		ts := strings.TrimPrefix(name, prefix)
+		ts, ok := strings.CutSuffix(ts, ext+".gz")
+		if !ok {
+			ts, ok = strings.CutSuffix(ts, ext)
+		}
+		if !ok {
+			continue
+		}
+
+		t, err := time.ParseInLocation(rotatingFileTimeFormat, ts, time.Local)
+		if err != nil {
+			continue
+		}
+		files = append(files, rotatedFile{path: filepath.Join(dir, name), time: t})
+	}
+
+	slices.SortFunc(files, func(a, b rotatedFile) int {
+		return b.time.Compare(a.time)
+	})
+	return files, nil
+}
+
+// maintain compresses and removes the rotated files.
+func (rf *RotatingFile) maintain() {
+	rf.maintainMu.Lock()
+	defer rf.maintainMu.Unlock()
+
+	files, err := rf.backups()
+	if err != nil {
+		slog.Error(fmt.Sprintf("[LOGGER] Error listing rotated log files: %s", err.Error()))
+		return
+	}
+
+	cutoff := rf.now().Add(-rf.maxAge)
+	for i, f := range files {
+		if (rf.maxBackups > 0 && i >= rf.maxBackups) || (rf.maxAge > 0 && f.time.Before(cutoff)) {
+			if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				slog.Error(fmt.Sprintf("[LOGGER] Error removing rotated log file: %s", err.Error()))
+			}
+			continue
+		}
+
+		if rf.compress && !strings.HasSuffix(f.path, ".gz") {
+			if err := compressFile(f.path); err != nil {
+				slog.Error(fmt.Sprintf("[LOGGER] Error compressing rotated log file: %s", err.Error()))
+			}
+		}
+	}
+}
+
+func compressFile(path string) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(path + ".gz")
+		}
+	}()
+
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err = errors.Join(gz.Close(), dst.Close()); err != nil {
+		return err
+	}
+
+	_ = src.Close()
+	return os.Remove(path)
+}
@@ -0,0 +1,375 @@
+package logger
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type testClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *testClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *testClock) add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func rotatingFileNames(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{}
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestNewRotatingFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "file"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		filename string
+		wantErr  bool
+	}{
+		{name: "new directory", filename: filepath.Join(dir, "logs", "app.log")},
+		{name: "invalid directory", filename: filepath.Join(dir, "file", "app.log"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rf, err := NewRotatingFile(tt.filename, WithReopenSignals())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewRotatingFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				defer rf.Close(context.Background())
+			}
+		})
+	}
+}
+
+func TestWithReopenSignals(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []OptionRotatingFile
+		want []os.Signal
+	}{
+		{name: "default"},
+		{name: "SIGHUP", opts: []OptionRotatingFile{WithReopenSignals(syscall.SIGHUP, nil)}, want: []os.Signal{syscall.SIGHUP}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rf, err := NewRotatingFile(filepath.Join(t.TempDir(), "app.log"), tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rf.Close(context.Background())
+
+			if !slices.Equal(rf.signals, tt.want) {
+				t.Errorf("WithReopenSignals() = %v, want %v", rf.signals, tt.want)
+			}
+			if got := rf.notify != nil; got != (len(tt.want) > 0) {
+				t.Errorf("NewRotatingFile() signal handler = %v, want %v", got, len(tt.want) > 0)
+			}
+		})
+	}
+}
+
+func TestRotatingFile_Write(t *testing.T) {
+	now := time.Date(2024, 1, 2, 10, 30, 0, 0, time.Local)
+
+	type args struct {
+		opts    []OptionRotatingFile
+		writes  []string
+		advance time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "without rotation",
+			args: args{writes: []string{"12345", "12345"}},
+			want: []string{"app.log"},
+		},
+		{
+			name: "max size",
+			args: args{opts: []OptionRotatingFile{WithMaxSize(8)}, writes: []string{"12345", "12345", "1234567890"}},
+			want: []string{
+				"app-2024-01-02T10-30-00.000.log",
+				"app-2024-01-02T10-30-00.001.log",
+				"app.log",
+			},
+		},
+		{
+			name: "interval",
+			args: args{opts: []OptionRotatingFile{WithRotateInterval(time.Hour)}, writes: []string{"1", "2", "3"}, advance: 40 * time.Minute},
+			want: []string{
+				"app-2024-01-02T11-10-00.000.log",
+				"app.log",
+			},
+		},
+		{
+			name: "max backups",
+			args: args{opts: []OptionRotatingFile{WithMaxSize(1), WithMaxBackups(1)}, writes: []string{"1", "2", "3"}},
+			want: []string{
+				"app-2024-01-02T10-30-00.001.log",
+				"app.log",
+			},
+		},
+		{
+			name: "max age",
+			args: args{opts: []OptionRotatingFile{WithMaxSize(1), WithMaxAge(30 * time.Minute)}, writes: []string{"1", "2", "3"}, advance: time.Hour},
+			want: []string{
+				"app-2024-01-02T12-30-00.000.log",
+				"app.log",
+			},
+		},
+		{
+			name: "compress",
+			args: args{opts: []OptionRotatingFile{WithMaxSize(1), WithCompress(true)}, writes: []string{"1", "2"}},
+			want: []string{
+				"app-2024-01-02T10-30-00.000.log.gz",
+				"app.log",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			clock := &testClock{t: now}
+
+			opts := append([]OptionRotatingFile{WithReopenSignals()}, tt.args.opts...)
+			rf, err := NewRotatingFile(filepath.Join(dir, "app.log"), opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rf.now = clock.now
+			rf.rotateAt = time.Time{}
+			if rf.interval > 0 {
+				rf.rotateAt = now.Truncate(rf.interval).Add(rf.interval)
+			}
+
+			for i, w := range tt.args.writes {
+				if i > 0 {
+					clock.add(tt.args.advance)
+				}
+				if _, err := rf.Write([]byte(w)); err != nil {
+					t.Fatalf("RotatingFile.Write() error = %v", err)
+				}
+			}
+
+			if err := rf.Close(context.Background()); err != nil {
+				t.Fatalf("RotatingFile.Close() error = %v", err)
+			}
+
+			if got := rotatingFileNames(t, dir); !slices.Equal(got, tt.want) {
+				t.Errorf("files = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_compressFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("message"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := compressFile(path); err != nil {
+		t.Fatalf("compressFile() error = %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("compressFile() did not remove the file")
+	}
+
+	f, err := os.Open(path + ".gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := io.ReadAll(gz)
+	if string(got) != "message" {
+		t.Errorf("compressFile() = %v, want %v", string(got), "message")
+	}
+}
+
+func TestRotatingFile_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "app.log")
+
+	rf, err := NewRotatingFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _ = rf.Write([]byte("1"))
+	if err := os.Rename(filename, filename+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rf.Reopen(); err != nil {
+		t.Fatalf("RotatingFile.Reopen() error = %v", err)
+	}
+	_, _ = rf.Write([]byte("2"))
+
+	if err := rf.Close(context.Background()); err != nil {
+		t.Fatalf("RotatingFile.Close() error = %v", err)
+	}
+	if err := rf.Close(context.Background()); err != nil {
+		t.Fatalf("RotatingFile.Close() error = %v", err)
+	}
+
+	for path, want := range map[string]string{filename: "2", filename + ".1": "1"} {
+		if got, _ := os.ReadFile(path); string(got) != want {
+			t.Errorf("%v = %v, want %v", filepath.Base(path), string(got), want)
+		}
+	}
+
+	if _, err := rf.Write([]byte("3")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("RotatingFile.Write() error = %v, want %v", err, os.ErrClosed)
+	}
+	if err := rf.Reopen(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("RotatingFile.Reopen() error = %v, want %v", err, os.ErrClosed)
+	}
+	if err := rf.Rotate(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("RotatingFile.Rotate() error = %v, want %v", err, os.ErrClosed)
+	}
+}
+
+func TestRotatingFile_concurrent(t *testing.T) {
+	dir := t.TempDir()
+	rf, err := NewRotatingFile(filepath.Join(dir, "app.log"), WithMaxSize(100), WithReopenSignals())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				_, _ = rf.Write([]byte("0123456789\n"))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := rf.Close(context.Background()); err != nil {
+		t.Fatalf("RotatingFile.Close() error = %v", err)
+	}
+
+	total := 0
+	for _, name := range rotatingFileNames(t, dir) {
+		b, _ := os.ReadFile(filepath.Join(dir, name))
+		for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+			if line != "0123456789" {
+				t.Fatalf("line = %q, want %q", line, "0123456789")
+			}
+			total++
+		}
+	}
+	if total != 100 {
+		t.Errorf("lines = %v, want %v", total, 100)
+	}
+}
+
+type recordChanHandler struct {
+	slog.Handler
+	records chan slog.Record
+}
+
+func (rh *recordChanHandler) Handle(ctx context.Context, r slog.Record) error {
+	rh.records <- r
+	return nil
+}
+
+func TestRotatingFile_Write_rotateError(t *testing.T) {
+	previous := slog.Default()
+	defer slog.SetDefault(previous)
+	rh := &recordChanHandler{Handler: slog.Default().Handler(), records: make(chan slog.Record, 10)}
+	slog.SetDefault(slog.New(rh))
+
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "app.log")
+	rf, err := NewRotatingFile(filename, WithMaxSize(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rf.now = (&testClock{t: time.Date(2024, 1, 2, 10, 30, 0, 0, time.Local)}).now
+
+	failures := 2
+	rf.rename = func(oldpath, newpath string) error {
+		if failures > 0 {
+			failures--
+			return errors.New("rename failed")
+		}
+		return os.Rename(oldpath, newpath)
+	}
+
+	for _, w := range []string{"1", "2", "3", "4"} {
+		if n, err := rf.Write([]byte(w)); n != 1 || err != nil {
+			t.Fatalf("RotatingFile.Write() = %v, %v, want %v, nil", n, err, 1)
+		}
+	}
+	if err := rf.Close(context.Background()); err != nil {
+		t.Fatalf("RotatingFile.Close() error = %v", err)
+	}
+
+	for path, want := range map[string]string{
+		filename: "4",
+		filepath.Join(dir, "app-2024-01-02T10-30-00.000.log"): "123",
+	} {
+		if got, _ := os.ReadFile(path); string(got) != want {
+			t.Errorf("%v = %v, want %v", filepath.Base(path), string(got), want)
+		}
+	}
+
+	select {
+	case r := <-rh.records:
+		if want := "[LOGGER] Error rotating log file: logger: renaming log file: rename failed"; r.Message != want {
+			t.Errorf("log = %v, want %v", r.Message, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("rotation error not logged")
+	}
+	select {
+	case r := <-rh.records:
+		t.Errorf("log = %v, want the rotation error logged once", r.Message)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
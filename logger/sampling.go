@@ -0,0 +1,206 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	sampler struct {
+		first       uint64
+		thereafter  uint64
+		interval    time.Duration
+		exempt      bool
+		exemptLevel slog.Level
+		summary     time.Duration
+		handler     slog.Handler
+		counters    [samplerCounters]samplerCounter
+		dropped     atomic.Uint64
+		scheduled   atomic.Bool
+		mu          sync.Mutex
+		timer       *time.Timer
+		closed      bool
+	}
+
+	samplerCounter struct {
+		mu      sync.Mutex
+		resetAt int64
+		count   uint64
+	}
+
+	// OptionSampling is used to apply configurations to the sampling stage when creating it with [WithSampling].
+	OptionSampling func(*sampler)
+)
+
+const samplerCounters = 4096
+
+// WithSampling is an [Option] that limits the number of records with the same message and level logged in each interval.
+// A variadic set of [OptionSampling] used to configure the sampling.
+//
+// Behavior:
+//   - The first records with the same message and level in each interval are logged, after that only every Mth record is logged.
+//   - Records are grouped by a hash of the message and level, distinct messages may share a counter in rare collisions.
+//   - The number of dropped records is reported in a summary record at the [slog.LevelWarn] level.
+//   - The counters are shared by the loggers derived with [slog.Logger.With], [slog.Logger.WithGroup] and [Named].
+//   - The pending summary record is logged when the handler is flushed or closed,
+//     closing the handler also stops the summary timer.
+//
+// Default:
+//   - The first 100 records and thereafter every 100th record per second are logged.
+//   - No level is exempt from sampling.
+//   - The summary record is logged every 10 seconds when records were dropped.
+//
+// Important Note:
+//   - The summary record is forwarded to the downstream handler by a timer, the handler created with [NewHandler]
+//     should be closed before the downstream handler, such as [AsyncHandler], see [NewHandler].
+func WithSampling(opts ...OptionSampling) Option {
+	return func(lh *loggerHandler) {
+		s := &sampler{
+			first:      100,
+			thereafter: 100,
+			interval:   time.Second,
+			summary:    10 * time.Second,
+		}
+
+		for _, opt := range opts {
+			opt(s)
+		}
+
+		lh.sampler = s
+	}
+}
+
+// WithSampleRate is an [OptionSampling] that defines the number of records logged in each interval before sampling starts,
+// and that every Mth record is logged after that. Zero thereafter drops every record after the first ones.
+func WithSampleRate(first, thereafter int) OptionSampling {
+	return func(s *sampler) {
+		s.first = uint64(max(first, 0))
+		s.thereafter = uint64(max(thereafter, 0))
+	}
+}
+
+// WithSampleInterval is an [OptionSampling] that defines the interval in which the records are counted.
+func WithSampleInterval(interval time.Duration) OptionSampling {
+	return func(s *sampler) {
+		if interval > 0 {
+			s.interval = interval
+		}
+	}
+}
+
+// WithSampleExemptLevel is an [OptionSampling] that defines the level from which records are never sampled, like [slog.LevelError].
+func WithSampleExemptLevel(level slog.Level) OptionSampling {
+	return func(s *sampler) {
+		s.exempt = true
+		s.exemptLevel = level
+	}
+}
+
+// WithSampleSummary is an [OptionSampling] that defines the interval of the summary record reporting the dropped records.
+// Zero disables the summary record.
+func WithSampleSummary(interval time.Duration) OptionSampling {
+	return func(s *sampler) {
+		s.summary = max(interval, 0)
+	}
+}
+
+// inc increments the counter, resetting it when the interval is over.
+// The reset and the increment are done under the lock, so each record of the interval gets a distinct count.
+func (sc *samplerCounter) inc(now int64, interval time.Duration) uint64 {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if sc.resetAt <= now {
+		sc.resetAt = now + interval.Nanoseconds()
+		sc.count = 0
+	}
+	sc.count++
+	return sc.count
+}
+
+// samplerKey returns the FNV-1a hash of the level and the message.
+func samplerKey(level slog.Level, msg string) uint32 {
+	h := uint32(2166136261)
+	h = (h ^ uint32(level)) * 16777619
+	for i := 0; i < len(msg); i++ {
+		h = (h ^ uint32(msg[i])) * 16777619
+	}
+	return h
+}
+
+// allow reports whether the record is logged, counting the dropped records.
+func (s *sampler) allow(r slog.Record) bool {
+	if s.exempt && r.Level >= s.exemptLevel {
+		return true
+	}
+
+	now := r.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	n := s.counters[samplerKey(r.Level, r.Message)%samplerCounters].inc(now.UnixNano(), s.interval)
+	if n <= s.first || (s.thereafter > 0 && (n-s.first)%s.thereafter == 0) {
+		return true
+	}
+
+	s.dropped.Add(1)
+	if s.summary > 0 && s.scheduled.CompareAndSwap(false, true) {
+		s.mu.Lock()
+		if !s.closed {
+			s.timer = time.AfterFunc(s.summary, s.report)
+		}
+		s.mu.Unlock()
+	}
+	return false
+}
+
+// report is called by the timer to log the summary record.
+func (s *sampler) report() {
+	s.mu.Lock()
+	s.timer = nil
+	s.scheduled.Store(false)
+	s.mu.Unlock()
+
+	s.logSummary()
+}
+
+// flush stops the timer and logs the pending summary record,
+// after close the timer is not scheduled again.
+func (s *sampler) flush(stop bool) {
+	s.mu.Lock()
+	s.closed = s.closed || stop
+	if s.timer != nil && s.timer.Stop() {
+		s.timer = nil
+		s.scheduled.Store(false)
+	}
+	s.mu.Unlock()
+
+	s.logSummary()
+}
+
+// logSummary logs the summary record with the number of records dropped since the last summary.
+func (s *sampler) logSummary() {
+	dropped := s.dropped.Swap(0)
+	if dropped == 0 || s.handler == nil {
+		return
+	}
+
+	ctx := context.Background()
+	if !s.handler.Enabled(ctx, slog.LevelWarn) {
+		return
+	}
+
+	r := slog.NewRecord(time.Now(), slog.LevelWarn, fmt.Sprintf("[LOGGER] Sampling dropped %d records", dropped), 0)
+	r.AddAttrs(slog.Group("sampling",
+		slog.Uint64("dropped", dropped),
+		slog.Uint64("first", s.first),
+		slog.Uint64("thereafter", s.thereafter),
+		slog.Duration("interval", s.interval),
+	))
+	_ = s.handler.Handle(ctx, r)
+}
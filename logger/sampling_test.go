@@ -0,0 +1,204 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_sampler_allowConcurrent(t *testing.T) {
+	const first = 10
+
+	lh := &loggerHandler{}
+	WithSampling(WithSampleRate(first, 0), WithSampleInterval(time.Second), WithSampleSummary(0))(lh)
+
+	// The first interval is exhausted, every goroutine starts at the boundary of the next interval.
+	start := time.Unix(0, 0)
+	for range 2 * first {
+		lh.sampler.allow(slog.NewRecord(start, slog.LevelInfo, "a", 0))
+	}
+	boundary := start.Add(time.Second)
+
+	var (
+		wg     sync.WaitGroup
+		ready  = make(chan struct{})
+		passed atomic.Uint64
+	)
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-ready
+			if lh.sampler.allow(slog.NewRecord(boundary, slog.LevelInfo, "a", 0)) {
+				passed.Add(1)
+			}
+		}()
+	}
+	close(ready)
+	wg.Wait()
+
+	if got := passed.Load(); got != first {
+		t.Errorf("sampler.allow() passed = %v, want %v", got, first)
+	}
+}
+
+func Test_sampler_allow(t *testing.T) {
+	now := time.Now()
+
+	type args struct {
+		level    slog.Level
+		messages []string
+		offset   time.Duration
+	}
+	tests := []struct {
+		name string
+		opts []OptionSampling
+		args args
+		want int
+	}{
+		{
+			name: "first",
+			opts: []OptionSampling{WithSampleRate(3, 0)},
+			args: args{level: slog.LevelInfo, messages: []string{"a", "a", "a", "a", "a"}},
+			want: 3,
+		},
+		{
+			name: "thereafter",
+			opts: []OptionSampling{WithSampleRate(2, 3)},
+			args: args{level: slog.LevelInfo, messages: []string{"a", "a", "a", "a", "a", "a", "a", "a"}},
+			want: 4,
+		},
+		{
+			name: "distinct messages",
+			opts: []OptionSampling{WithSampleRate(1, 0)},
+			args: args{level: slog.LevelInfo, messages: []string{"a", "b", "a", "b", "c"}},
+			want: 3,
+		},
+		{
+			name: "exempt level",
+			opts: []OptionSampling{WithSampleRate(1, 0), WithSampleExemptLevel(slog.LevelError)},
+			args: args{level: slog.LevelError, messages: []string{"a", "a", "a"}},
+			want: 3,
+		},
+		{
+			name: "below exempt level",
+			opts: []OptionSampling{WithSampleRate(1, 0), WithSampleExemptLevel(slog.LevelError)},
+			args: args{level: slog.LevelWarn, messages: []string{"a", "a", "a"}},
+			want: 1,
+		},
+		{
+			name: "interval reset",
+			opts: []OptionSampling{WithSampleRate(1, 0), WithSampleInterval(time.Second)},
+			args: args{level: slog.LevelInfo, messages: []string{"a", "a", "a"}, offset: time.Second},
+			want: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lh := &loggerHandler{}
+			WithSampling(append(tt.opts, WithSampleSummary(0))...)(lh)
+
+			got := 0
+			for i, msg := range tt.args.messages {
+				r := slog.NewRecord(now.Add(time.Duration(i)*tt.args.offset), tt.args.level, msg, 0)
+				if lh.sampler.allow(r) {
+					got++
+				}
+			}
+
+			if got != tt.want {
+				t.Errorf("sampler.allow() = %v, want %v", got, tt.want)
+			}
+			if dropped := lh.sampler.dropped.Load(); dropped != uint64(len(tt.args.messages)-tt.want) {
+				t.Errorf("sampler.dropped = %v, want %v", dropped, len(tt.args.messages)-tt.want)
+			}
+		})
+	}
+}
+
+func TestWithSampling(t *testing.T) {
+	var buf strings.Builder
+	var mu sync.Mutex
+	l := NewLogger(
+		WithSampling(
+			WithSampleRate(10, 0),
+			WithSampleSummary(20*time.Millisecond),
+		),
+		WithHandler(slog.NewTextHandler(&lockedWriter{mu: &mu, w: &buf}, nil)),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				l.With("worker", i).WithGroup("g").InfoContext(context.Background(), "message")
+			}
+		}()
+	}
+	wg.Wait()
+
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	got := buf.String()
+	mu.Unlock()
+
+	if n := strings.Count(got, "msg=message"); n != 10 {
+		t.Errorf("records = %v, want %v", n, 10)
+	}
+	if !strings.Contains(got, `msg="[LOGGER] Sampling dropped 90 records" sampling.dropped=90`) {
+		t.Errorf("log = %v, want summary record", got)
+	}
+}
+
+func TestWithSampling_close(t *testing.T) {
+	var buf strings.Builder
+	var mu sync.Mutex
+	h := NewHandler(
+		WithSampling(
+			WithSampleRate(1, 0),
+			WithSampleSummary(time.Hour),
+		),
+		WithHandler(slog.NewTextHandler(&lockedWriter{mu: &mu, w: &buf}, nil)),
+	).(*loggerHandler)
+	l := slog.New(h)
+
+	for range 3 {
+		l.Info("message")
+	}
+	if err := h.Close(context.Background()); err != nil {
+		t.Fatalf("loggerHandler.Close() error = %v", err)
+	}
+	l.Info("message")
+
+	h.sampler.mu.Lock()
+	timer := h.sampler.timer
+	h.sampler.mu.Unlock()
+	if timer != nil {
+		t.Errorf("sampler.timer = %v, want nil after close", timer)
+	}
+
+	mu.Lock()
+	got := buf.String()
+	mu.Unlock()
+	if !strings.Contains(got, `msg="[LOGGER] Sampling dropped 2 records" sampling.dropped=2`) {
+		t.Errorf("log = %v, want summary record", got)
+	}
+}
+
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  *strings.Builder
+}
+
+func (lw *lockedWriter) Write(p []byte) (int, error) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	return lw.w.Write(p)
+}
@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"go/build"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type (
+	sourceOptions struct {
+		trimPrefixes []string
+		fileLine     bool
+		stack        bool
+		stackLevel   slog.Level
+	}
+
+	// OptionSource is used to apply configurations to the source code information of [WithMaxLevelAddSource].
+	OptionSource func(*sourceOptions)
+)
+
+const maxSourceStackDepth = 64
+
+// WithSourceTrimPrefix is an [OptionSource] that removes the first matching prefix from source.file,
+// like the module root directory, or the module path when the application is built with -trimpath.
+//
+// Example:
+//
+//	logger.WithMaxLevelAddSource(slog.LevelDebug, logger.WithSourceTrimPrefix("github.com/telmoandrade/go-library/"))
+func WithSourceTrimPrefix(prefixes ...string) OptionSource {
+	return func(so *sourceOptions) {
+		so.trimPrefixes = append(so.trimPrefixes, prefixes...)
+	}
+}
+
+// WithSourceTrimGOPATH is an [OptionSource] that removes the GOPATH from source.file,
+// the files of the dependencies become like github.com/foo/bar@v1.2.3/file.go.
+//
+// Behavior:
+//   - The GOPATH is read from the environment variable, or the default of the go command when it is not set.
+//   - Both the module cache GOPATH/pkg/mod and the GOPATH/src directory are removed.
+func WithSourceTrimGOPATH() OptionSource {
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		gopath = build.Default.GOPATH
+	}
+
+	var prefixes []string
+	for _, p := range filepath.SplitList(gopath) {
+		p = filepath.ToSlash(p)
+		prefixes = append(prefixes, p+"/pkg/mod/", p+"/src/")
+	}
+	return WithSourceTrimPrefix(prefixes...)
+}
+
+// WithSourceFileLine is an [OptionSource] that adds the source code information as a single attribute source=file:line,
+// instead of the source group with the function, file and line.
+func WithSourceFileLine() OptionSource {
+	return func(so *sourceOptions) {
+		so.fileLine = true
+	}
+}
+
+// WithSourceStack is an [OptionSource] that adds the attribute stack with the stack trace of the goroutine,
+// to the log entries at or above the level, like [slog.LevelError].
+// It is independent of the maximum level of [WithMaxLevelAddSource], the stack starts at the caller of the log function.
+func WithSourceStack(level slog.Level) OptionSource {
+	return func(so *sourceOptions) {
+		so.stack = true
+		so.stackLevel = level
+	}
+}
+
+func (so sourceOptions) file(file string) string {
+	for _, prefix := range so.trimPrefixes {
+		if prefix != "" && strings.HasPrefix(file, prefix) {
+			return strings.TrimPrefix(file, prefix)
+		}
+	}
+	return file
+}
+
+func (so sourceOptions) attr(f runtime.Frame) slog.Attr {
+	file := so.file(f.File)
+
+	if so.fileLine {
+		return slog.String("source", file+":"+strconv.Itoa(f.Line))
+	}
+
+	return slog.Group("source",
+		slog.String("function", f.Function),
+		slog.String("file", file),
+		slog.Int("line", f.Line),
+	)
+}
+
+func (so sourceOptions) addStack(r slog.Record) bool {
+	return so.stack && r.PC != 0 && r.Level >= so.stackLevel
+}
+
+// sourceStack returns the stack trace of the goroutine starting at the frame of the pc,
+// the whole stack is returned if the record is handled in another goroutine.
+func sourceStack(pc uintptr) []string {
+	pcs := make([]uintptr, maxSourceStackDepth)
+	pcs = pcs[:runtime.Callers(2, pcs)]
+	if i := slices.Index(pcs, pc); i >= 0 {
+		pcs = pcs[i:]
+	}
+	return formatStack(pcs)
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"go/build"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithSourceTrimGOPATH(t *testing.T) {
+	tests := []struct {
+		name   string
+		gopath string
+		want   []string
+	}{
+		{name: "env", gopath: "/go" + string(filepath.ListSeparator) + "/home/go", want: []string{"/go/pkg/mod/", "/go/src/", "/home/go/pkg/mod/", "/home/go/src/"}},
+		{name: "default", gopath: "", want: []string{filepath.ToSlash(build.Default.GOPATH) + "/pkg/mod/", filepath.ToSlash(build.Default.GOPATH) + "/src/"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GOPATH", tt.gopath)
+
+			so := sourceOptions{}
+			WithSourceTrimGOPATH()(&so)
+			if strings.Join(so.trimPrefixes, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("WithSourceTrimGOPATH() = %v, want %v", so.trimPrefixes, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sourceOptions_attr(t *testing.T) {
+	f := runtime.Frame{Function: "github.com/foo/bar.Run", File: "/go/pkg/mod/github.com/foo/bar@v1.0.0/run.go", Line: 42}
+
+	tests := []struct {
+		name string
+		opts []OptionSource
+		want slog.Attr
+	}{
+		{
+			name: "default",
+			want: slog.Group("source", slog.String("function", f.Function), slog.String("file", f.File), slog.Int("line", 42)),
+		},
+		{
+			name: "trim prefix",
+			opts: []OptionSource{WithSourceTrimPrefix("", "/app/", "/go/pkg/mod/")},
+			want: slog.Group("source", slog.String("function", f.Function), slog.String("file", "github.com/foo/bar@v1.0.0/run.go"), slog.Int("line", 42)),
+		},
+		{
+			name: "file line",
+			opts: []OptionSource{WithSourceTrimPrefix("/go/pkg/mod/github.com/foo/"), WithSourceFileLine()},
+			want: slog.String("source", "bar@v1.0.0/run.go:42"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lh := &loggerHandler{}
+			WithMaxLevelAddSource(slog.LevelDebug, tt.opts...)(lh)
+
+			if got := lh.source.attr(f); !got.Equal(tt.want) {
+				t.Errorf("sourceOptions.attr() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithSourceStack(t *testing.T) {
+	tests := []struct {
+		name      string
+		level     slog.Level
+		pc        bool
+		wantStack bool
+	}{
+		{name: "below level", level: slog.LevelWarn, pc: true, wantStack: false},
+		{name: "at level", level: slog.LevelError, pc: true, wantStack: true},
+		{name: "without pc", level: slog.LevelError, pc: false, wantStack: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := NewHandler(
+				WithHandler(slog.NewJSONHandler(&buf, nil)),
+				WithMaxLevelAddSource(slog.LevelError, WithSourceStack(slog.LevelError), WithSourceFileLine()),
+			)
+
+			if tt.pc {
+				slog.New(h).Log(context.Background(), tt.level, "msg")
+			} else if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), tt.level, "msg", 0)); err != nil {
+				t.Fatalf("loggerHandler.Handle() error = %v", err)
+			}
+
+			var entry struct {
+				Source *string
+				Stack  []string
+			}
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatal(err)
+			}
+			if (entry.Source != nil) != tt.pc {
+				t.Errorf("source = %v, want %v", entry.Source != nil, tt.pc)
+			}
+			if (len(entry.Stack) > 0) != tt.wantStack {
+				t.Fatalf("stack = %v, want %v", entry.Stack, tt.wantStack)
+			}
+			if tt.wantStack && !strings.Contains(entry.Stack[0], "TestWithSourceStack") {
+				t.Errorf("stack[0] = %v, want TestWithSourceStack", entry.Stack[0])
+			}
+		})
+	}
+}
+
+func Test_sourceStack(t *testing.T) {
+	stack := sourceStack(0)
+	if len(stack) == 0 || !strings.Contains(stack[0], "Test_sourceStack") {
+		t.Errorf("sourceStack() = %v, want the whole stack", stack)
+	}
+}